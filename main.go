@@ -29,4 +29,79 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+
+	err = converter.ParseFeats(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseOptionalFeatures(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseConditions(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseActions(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseSenses(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseSkills(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseLanguages(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseVariantRules(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseDeities(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseTrapsHazards(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseVehicles(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseObjects(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseTables(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseBooks(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	err = converter.ParseAdventures(ctx)
+	if err != nil {
+		log.Fatal(err)
+	}
 }
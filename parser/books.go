@@ -0,0 +1,273 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// BookIndexFile represents the structure of the books.json file
+type BookIndexFile struct {
+	Book []BookInfo `json:"book"`
+}
+
+// AdventureIndexFile represents the structure of the adventures.json file
+type AdventureIndexFile struct {
+	Adventure []BookInfo `json:"adventure"`
+}
+
+// BookInfo represents the index entry for a sourcebook or adventure
+type BookInfo struct {
+	Name      string          `json:"name"`
+	ID        string          `json:"id"`
+	Source    string          `json:"source"`
+	Group     string          `json:"group,omitempty"`
+	Published string          `json:"published,omitempty"`
+	Author    string          `json:"author,omitempty"`
+	Storyline string          `json:"storyline,omitempty"`
+	Level     *AdventureLevel `json:"level,omitempty"`
+}
+
+// AdventureLevel represents the range of character levels an adventure is written for
+type AdventureLevel struct {
+	Start  int    `json:"start,omitempty"`
+	End    int    `json:"end,omitempty"`
+	Custom string `json:"custom,omitempty"`
+}
+
+// BookDataFile represents the structure of a book-*.json or adventure-*.json file
+type BookDataFile struct {
+	Data []interface{} `json:"data"`
+}
+
+// bookNote is a single note generated from a section of a book
+type bookNote struct {
+	Name     string
+	Title    string
+	Depth    int
+	Content  string
+	Children []bookNote
+}
+
+// bookKind describes where a collection of books is read from and written to
+type bookKind struct {
+	label     string // Shown under the title of the index note
+	indexFile string
+	folder    string // Folder containing the full text, also used as the file prefix
+	outFolder string
+}
+
+var (
+	sourcebookKind = bookKind{label: "Sourcebook", indexFile: "books.json", folder: "book", outFolder: "books"}
+	adventureKind  = bookKind{label: "Adventure", indexFile: "adventures.json", folder: "adventure", outFolder: "adventures"}
+)
+
+// parseBooks parses the sourcebook data from the specified directory and writes a folder of notes per book.
+// A sectionDepth of 0 writes one note per chapter; higher values also split out named sections that deep.
+func parseBooks(ctx context.Context, dataDirectory, outDirectory string, sectionDepth int) error {
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, sourcebookKind.indexFile))
+	if err != nil {
+		return fmt.Errorf("failed to read book index file: %w", err)
+	}
+
+	var indexFile BookIndexFile
+	if err := json.Unmarshal(fileData, &indexFile); err != nil {
+		return fmt.Errorf("failed to parse book index file: %w", err)
+	}
+
+	return parseBookCollection(dataDirectory, outDirectory, sourcebookKind, indexFile.Book, sectionDepth)
+}
+
+// parseAdventures parses the adventure data from the specified directory and writes a folder of notes per
+// adventure. The sectionDepth works as for parseBooks.
+func parseAdventures(ctx context.Context, dataDirectory, outDirectory string, sectionDepth int) error {
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, adventureKind.indexFile))
+	if err != nil {
+		return fmt.Errorf("failed to read adventure index file: %w", err)
+	}
+
+	var indexFile AdventureIndexFile
+	if err := json.Unmarshal(fileData, &indexFile); err != nil {
+		return fmt.Errorf("failed to parse adventure index file: %w", err)
+	}
+
+	return parseBookCollection(dataDirectory, outDirectory, adventureKind, indexFile.Adventure, sectionDepth)
+}
+
+// parseBookCollection writes the notes for every book in the index that has its full text available
+func parseBookCollection(dataDirectory, outDirectory string, kind bookKind, books []BookInfo, sectionDepth int) error {
+	for _, book := range books {
+		// Only the books we have the full text for are converted
+		dataPath := filepath.Join(dataDirectory, kind.folder, fmt.Sprintf("%s-%s.json", kind.folder, strings.ToLower(book.ID)))
+		fileData, err := os.ReadFile(dataPath)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to read %s file: %w", kind.folder, err)
+		}
+
+		var dataFile BookDataFile
+		if err := json.Unmarshal(fileData, &dataFile); err != nil {
+			return fmt.Errorf("failed to parse %s file %s: %w", kind.folder, book.ID, err)
+		}
+
+		// Create output directory if it doesn't exist
+		outDir := filepath.Join(outDirectory, kind.outFolder, safeFileName(book.Name))
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		notes := bookToNotes(book, dataFile.Data, sectionDepth)
+		for _, note := range notes {
+			if err := writeNote(outDir, note.Name, note.Content); err != nil {
+				return err
+			}
+		}
+
+		// Write the book index
+		mdContent, err := bookIndexToMarkdown(book, kind.label, notes)
+		if err != nil {
+			return fmt.Errorf("failed to convert %s index to markdown: %w", kind.folder, err)
+		}
+		if err := writeNote(outDir, book.Name, mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// bookToNotes splits the full text of a book into notes. Each top level entry is a chapter, and named
+// sections are split into their own notes until sectionDepth is reached.
+func bookToNotes(book BookInfo, data []interface{}, sectionDepth int) []bookNote {
+	var notes []bookNote
+	chapter := 0
+	for _, entry := range data {
+		section, ok := entry.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		chapter++
+		prefix := fmt.Sprintf("%s %02d", book.ID, chapter)
+		notes = appendSectionNotes(notes, book, section, prefix, "", 0, sectionDepth)
+	}
+	return notes
+}
+
+// appendSectionNotes appends the note for a section, followed by the notes for any sections split out of it
+func appendSectionNotes(notes []bookNote, book BookInfo, section map[string]interface{}, prefix, parent string, depth, sectionDepth int) []bookNote {
+	title, _ := section["name"].(string)
+	title = processSpecialFormatting(title)
+	if title == "" {
+		title = "Untitled"
+	}
+
+	note := bookNote{
+		Name:  prefix + " " + title,
+		Title: title,
+		Depth: depth,
+	}
+	entries, _ := section["entries"].([]interface{})
+
+	// Split named child sections into their own notes, keeping everything else in this note
+	var body []interface{}
+	var children []bookNote
+	child := 0
+	for _, entry := range entries {
+		if depth < sectionDepth && isBookSection(entry) {
+			child++
+			childPrefix := fmt.Sprintf("%s-%02d", prefix, child)
+			childNotes := appendSectionNotes(nil, book, entry.(map[string]interface{}), childPrefix, note.Name, depth+1, sectionDepth)
+			note.Children = append(note.Children, childNotes[0])
+			children = append(children, childNotes...)
+			continue
+		}
+		body = append(body, entry)
+	}
+
+	var md strings.Builder
+	md.WriteString(fmt.Sprintf("# %s\n\n", title))
+	md.WriteString(fmt.Sprintf("**Book:** %s\n\n", linkTo(book.Name)))
+	if parent != "" {
+		md.WriteString(fmt.Sprintf("**Part of:** [[%s]]\n\n", safeFileName(parent)))
+	}
+
+	entriesToMarkdown(&md, body, 2)
+
+	if len(note.Children) > 0 {
+		md.WriteString("## Sections\n\n")
+		for _, childNote := range note.Children {
+			md.WriteString(fmt.Sprintf("- [[%s|%s]]\n", safeFileName(childNote.Name), childNote.Title))
+		}
+		md.WriteString("\n")
+	}
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", book.Source))
+	if page, ok := section["page"].(float64); ok && page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", int(page)))
+	}
+	md.WriteString("\n")
+
+	note.Content = md.String()
+	return append(append(notes, note), children...)
+}
+
+// isBookSection reports whether an entry is a named section that can be split into its own note
+func isBookSection(entry interface{}) bool {
+	e, ok := entry.(map[string]interface{})
+	if !ok {
+		return false
+	}
+	entryType, _ := e["type"].(string)
+	name, _ := e["name"].(string)
+	return (entryType == "section" || entryType == "entries") && name != ""
+}
+
+// bookIndexToMarkdown converts a book's metadata and table of contents to Markdown format
+func bookIndexToMarkdown(book BookInfo, label string, notes []bookNote) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", book.Name))
+	md.WriteString(fmt.Sprintf("*%s*\n\n", label))
+
+	// Metadata
+	if book.Storyline != "" {
+		md.WriteString(fmt.Sprintf("**Storyline:** %s\n\n", book.Storyline))
+	}
+	if book.Level != nil {
+		switch {
+		case book.Level.Custom != "":
+			md.WriteString(fmt.Sprintf("**Levels:** %s\n\n", book.Level.Custom))
+		case book.Level.Start > 0 && book.Level.End > book.Level.Start:
+			md.WriteString(fmt.Sprintf("**Levels:** %d–%d\n\n", book.Level.Start, book.Level.End))
+		case book.Level.Start > 0:
+			md.WriteString(fmt.Sprintf("**Level:** %d\n\n", book.Level.Start))
+		}
+	}
+	if book.Author != "" {
+		md.WriteString(fmt.Sprintf("**Author:** %s\n\n", book.Author))
+	}
+	if book.Published != "" {
+		md.WriteString(fmt.Sprintf("**Published:** %s\n\n", book.Published))
+	}
+
+	// Contents
+	if len(notes) > 0 {
+		md.WriteString("## Contents\n\n")
+		for _, note := range notes {
+			md.WriteString(fmt.Sprintf("%s- [[%s|%s]]\n", strings.Repeat("    ", note.Depth), safeFileName(note.Name), note.Title))
+		}
+		md.WriteString("\n")
+	}
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s\n", book.Source))
+
+	return md.String(), nil
+}
@@ -0,0 +1,196 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testBookData() []interface{} {
+	return []interface{}{
+		map[string]interface{}{
+			"type": "section",
+			"name": "Introduction",
+			"page": float64(4),
+			"entries": []interface{}{
+				"Welcome to the adventure.",
+				map[string]interface{}{
+					"type":    "insetReadaloud",
+					"entries": []interface{}{"The road winds ahead."},
+				},
+			},
+		},
+		map[string]interface{}{
+			"type": "section",
+			"name": "Goblin Arrows",
+			"entries": []interface{}{
+				"Goblins attack the party.",
+				map[string]interface{}{
+					"type": "entries",
+					"name": "Goblin Ambush",
+					"entries": []interface{}{
+						"Four goblins hide in the thicket.",
+						map[string]interface{}{"type": "statblock", "tag": "creature", "name": "Goblin", "source": "MM"},
+					},
+				},
+			},
+		},
+	}
+}
+
+func TestBookToNotes(t *testing.T) {
+	book := BookInfo{Name: "Lost Mine of Phandelver", ID: "LMoP", Source: "LMoP"}
+
+	tests := []struct {
+		name         string
+		sectionDepth int
+		expected     []string
+	}{
+		{
+			name:         "One note per chapter",
+			sectionDepth: 0,
+			expected:     []string{"LMoP 01 Introduction", "LMoP 02 Goblin Arrows"},
+		},
+		{
+			name:         "Split sections",
+			sectionDepth: 1,
+			expected:     []string{"LMoP 01 Introduction", "LMoP 02 Goblin Arrows", "LMoP 02-01 Goblin Ambush"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notes := bookToNotes(book, testBookData(), tt.sectionDepth)
+			if len(notes) != len(tt.expected) {
+				t.Fatalf("bookToNotes() returned %d notes, want %d", len(notes), len(tt.expected))
+			}
+			for i, note := range notes {
+				if note.Name != tt.expected[i] {
+					t.Errorf("bookToNotes()[%d].Name = %q, want %q", i, note.Name, tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBookToNotes_Content(t *testing.T) {
+	book := BookInfo{Name: "Lost Mine of Phandelver", ID: "LMoP", Source: "LMoP"}
+
+	notes := bookToNotes(book, testBookData(), 0)
+	expectedIntroduction := "# Introduction\n\n" +
+		"**Book:** [[Lost Mine of Phandelver]]\n\n" +
+		"Welcome to the adventure.\n\n" +
+		"> The road winds ahead.\n\n" +
+		"**Source:** LMoP, page 4\n"
+	if notes[0].Content != expectedIntroduction {
+		t.Errorf("bookToNotes() introduction = %q, want %q", notes[0].Content, expectedIntroduction)
+	}
+
+	expectedStrings := []string{
+		"## Goblin Ambush",
+		"**Stat Block:** [[Goblin]]",
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(notes[1].Content, expected) {
+			t.Errorf("bookToNotes() chapter missing %q, got %s", expected, notes[1].Content)
+		}
+	}
+
+	split := bookToNotes(book, testBookData(), 1)
+	if !strings.Contains(split[1].Content, "## Sections\n\n- [[LMoP 02-01 Goblin Ambush|Goblin Ambush]]") {
+		t.Errorf("bookToNotes() chapter missing section links, got %s", split[1].Content)
+	}
+	if !strings.Contains(split[2].Content, "**Part of:** [[LMoP 02 Goblin Arrows]]") {
+		t.Errorf("bookToNotes() section missing parent link, got %s", split[2].Content)
+	}
+}
+
+func TestBookIndexToMarkdown(t *testing.T) {
+	book := BookInfo{
+		Name:      "Lost Mine of Phandelver",
+		ID:        "LMoP",
+		Source:    "LMoP",
+		Published: "2014-07-15",
+		Storyline: "Starter Set",
+		Level:     &AdventureLevel{Start: 1, End: 5},
+	}
+	notes := bookToNotes(book, testBookData(), 1)
+
+	result, err := bookIndexToMarkdown(book, "Adventure", notes)
+	if err != nil {
+		t.Fatalf("bookIndexToMarkdown() error = %v", err)
+	}
+
+	expected := "# Lost Mine of Phandelver\n\n" +
+		"*Adventure*\n\n" +
+		"**Storyline:** Starter Set\n\n" +
+		"**Levels:** 1–5\n\n" +
+		"**Published:** 2014-07-15\n\n" +
+		"## Contents\n\n" +
+		"- [[LMoP 01 Introduction|Introduction]]\n" +
+		"- [[LMoP 02 Goblin Arrows|Goblin Arrows]]\n" +
+		"    - [[LMoP 02-01 Goblin Ambush|Goblin Ambush]]\n\n" +
+		"**Source:** LMoP\n"
+	if result != expected {
+		t.Errorf("bookIndexToMarkdown() = %q, want %q", result, expected)
+	}
+}
+
+func TestParseAdventures_WithMockData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "books-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	if err := os.MkdirAll(filepath.Join(dataDir, "adventure"), 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	indexFile := AdventureIndexFile{
+		Adventure: []BookInfo{
+			{Name: "Lost Mine of Phandelver", ID: "LMoP", Source: "LMoP"},
+			{Name: "Missing Adventure", ID: "MA", Source: "MA"},
+		},
+	}
+	data, err := json.Marshal(indexFile)
+	if err != nil {
+		t.Fatalf("Failed to marshal adventure index: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "adventures.json"), data, 0644); err != nil {
+		t.Fatalf("Failed to write adventures.json: %v", err)
+	}
+
+	data, err = json.Marshal(BookDataFile{Data: testBookData()})
+	if err != nil {
+		t.Fatalf("Failed to marshal adventure data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "adventure", "adventure-lmop.json"), data, 0644); err != nil {
+		t.Fatalf("Failed to write adventure-lmop.json: %v", err)
+	}
+
+	if err := parseAdventures(context.Background(), dataDir, outDir, 0); err != nil {
+		t.Fatalf("parseAdventures() error = %v", err)
+	}
+
+	expectedFiles := []string{
+		"Lost Mine of Phandelver.md",
+		"LMoP 01 Introduction.md",
+		"LMoP 02 Goblin Arrows.md",
+	}
+	for _, expectedFile := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(outDir, "adventures", "Lost Mine of Phandelver", expectedFile)); os.IsNotExist(err) {
+			t.Errorf("Expected file %s does not exist", expectedFile)
+		}
+	}
+
+	// Adventures without their full text are skipped
+	if _, err := os.Stat(filepath.Join(outDir, "adventures", "Missing Adventure")); !os.IsNotExist(err) {
+		t.Errorf("Expected no output for an adventure without data")
+	}
+}
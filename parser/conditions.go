@@ -0,0 +1,155 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ConditionFile represents the structure of the conditionsdiseases.json file
+type ConditionFile struct {
+	Condition []Condition `json:"condition"`
+	Disease   []Condition `json:"disease"`
+	Status    []Condition `json:"status"`
+}
+
+// Condition represents a single condition, disease or status entry
+type Condition struct {
+	Name    string        `json:"name"`
+	Source  string        `json:"source"`
+	Page    int           `json:"page,omitempty"`
+	Entries []interface{} `json:"entries,omitempty"`
+}
+
+// ConditionFluffFile represents the structure of the fluff-conditionsdiseases.json file
+type ConditionFluffFile struct {
+	ConditionFluff []Fluff `json:"conditionFluff"`
+	DiseaseFluff   []Fluff `json:"diseaseFluff"`
+	StatusFluff    []Fluff `json:"statusFluff"`
+}
+
+// Fluff represents the lore and images for an entity, stored separately from its rules text
+type Fluff struct {
+	Name    string        `json:"name"`
+	Source  string        `json:"source"`
+	Entries []interface{} `json:"entries,omitempty"`
+	Images  []interface{} `json:"images,omitempty"`
+}
+
+// parseConditions parses the condition, disease and status data from the specified directory and writes
+// it to the output directory.
+func parseConditions(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Read and parse the condition file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "conditionsdiseases.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read condition file: %w", err)
+	}
+
+	var conditionFile ConditionFile
+	if err := json.Unmarshal(fileData, &conditionFile); err != nil {
+		return fmt.Errorf("failed to parse condition file: %w", err)
+	}
+
+	// Fluff is optional, so a missing file is not an error
+	var fluffFile ConditionFluffFile
+	fluffData, err := os.ReadFile(filepath.Join(dataDirectory, "fluff-conditionsdiseases.json"))
+	if err == nil {
+		if err := json.Unmarshal(fluffData, &fluffFile); err != nil {
+			return fmt.Errorf("failed to parse condition fluff file: %w", err)
+		}
+	} else if !os.IsNotExist(err) {
+		return fmt.Errorf("failed to read condition fluff file: %w", err)
+	}
+
+	groups := []struct {
+		kind       string
+		folder     string
+		conditions []Condition
+		fluff      []Fluff
+	}{
+		{"Condition", "conditions", conditionFile.Condition, fluffFile.ConditionFluff},
+		{"Disease", "diseases", conditionFile.Disease, fluffFile.DiseaseFluff},
+		{"Status", "statuses", conditionFile.Status, fluffFile.StatusFluff},
+	}
+
+	for _, group := range groups {
+		if len(group.conditions) == 0 {
+			continue
+		}
+
+		// Create output directory if it doesn't exist
+		outDir := filepath.Join(outDirectory, group.folder)
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		fluffLookup := newFluffLookup(group.fluff)
+
+		// Process each condition
+		for _, condition := range group.conditions {
+			mdContent, err := conditionToMarkdown(condition, group.kind, fluffLookup.find(condition.Name, condition.Source))
+			if err != nil {
+				return fmt.Errorf("failed to convert %s to markdown: %w", strings.ToLower(group.kind), err)
+			}
+
+			if err := writeNote(outDir, condition.Name, mdContent); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// conditionToMarkdown converts a condition, disease or status to Markdown format.
+// The fluff is optional and is rendered after the rules text when present.
+func conditionToMarkdown(condition Condition, kind string, fluff *Fluff) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", condition.Name))
+	md.WriteString(fmt.Sprintf("*%s*\n\n", kind))
+
+	// Description
+	entriesToMarkdown(&md, condition.Entries, 2)
+
+	// Lore
+	if fluff != nil && len(fluff.Entries) > 0 {
+		md.WriteString("## Lore\n\n")
+		entriesToMarkdown(&md, fluff.Entries, 3)
+	}
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", condition.Source))
+	if condition.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", condition.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// fluffLookup finds fluff entries by name and source
+type fluffLookup map[string]*Fluff
+
+// newFluffLookup indexes a list of fluff entries by name and source
+func newFluffLookup(fluffs []Fluff) fluffLookup {
+	lookup := make(fluffLookup, len(fluffs))
+	for i := range fluffs {
+		lookup[fluffKey(fluffs[i].Name, fluffs[i].Source)] = &fluffs[i]
+	}
+	return lookup
+}
+
+// find returns the fluff for the given name and source, or nil when there is none
+func (l fluffLookup) find(name, source string) *Fluff {
+	return l[fluffKey(name, source)]
+}
+
+// fluffKey returns the case-insensitive key used to match fluff with its entity
+func fluffKey(name, source string) string {
+	return strings.ToLower(name) + "|" + strings.ToLower(source)
+}
@@ -0,0 +1,120 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestConditionToMarkdown(t *testing.T) {
+	condition := Condition{
+		Name:   "Poisoned",
+		Source: "PHB",
+		Page:   292,
+		Entries: []interface{}{
+			map[string]interface{}{
+				"type": "list",
+				"items": []interface{}{
+					"A poisoned creature has disadvantage on attack rolls and ability checks.",
+				},
+			},
+		},
+	}
+	fluff := &Fluff{
+		Name:    "Poisoned",
+		Source:  "PHB",
+		Entries: []interface{}{"Poisons come in many forms."},
+	}
+
+	md, err := conditionToMarkdown(condition, "Condition", fluff)
+	if err != nil {
+		t.Fatalf("conditionToMarkdown() error = %v", err)
+	}
+
+	expected := "# Poisoned\n\n*Condition*\n\n- A poisoned creature has disadvantage on attack rolls and ability checks.\n\n## Lore\n\nPoisons come in many forms.\n\n**Source:** PHB, page 292\n"
+	if md != expected {
+		t.Errorf("conditionToMarkdown() = %q, want %q", md, expected)
+	}
+}
+
+func TestProcessConditionTag(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"The target is {@condition poisoned}.", "The target is [[poisoned]]."},
+		{"It is {@condition blinded|XPHB} and {@status concentration||concentrating}.", "It is [[blinded]] and [[concentration|concentrating]]."},
+		{"Contracts {@disease sewer plague}.", "Contracts [[sewer plague]]."},
+	}
+
+	for _, test := range tests {
+		result := processConditionTag(test.input)
+		if result != test.expected {
+			t.Errorf("processConditionTag(%q) = %q; want %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestParseConditions_WithMockData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "conditions-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	conditionFile := ConditionFile{
+		Condition: []Condition{{Name: "Blinded", Source: "PHB", Entries: []interface{}{"A blinded creature can't see."}}},
+		Disease:   []Condition{{Name: "Sewer Plague", Source: "DMG", Entries: []interface{}{"Sewer plague is a generic term."}}},
+		Status:    []Condition{{Name: "Surprised", Source: "PHB", Entries: []interface{}{"You can't move or take an action."}}},
+	}
+	conditionData, err := json.Marshal(conditionFile)
+	if err != nil {
+		t.Fatalf("Failed to marshal condition data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "conditionsdiseases.json"), conditionData, 0644); err != nil {
+		t.Fatalf("Failed to write conditionsdiseases.json: %v", err)
+	}
+
+	fluffFile := ConditionFluffFile{
+		ConditionFluff: []Fluff{{Name: "Blinded", Source: "PHB", Entries: []interface{}{"Darkness is everywhere."}}},
+	}
+	fluffData, err := json.Marshal(fluffFile)
+	if err != nil {
+		t.Fatalf("Failed to marshal fluff data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "fluff-conditionsdiseases.json"), fluffData, 0644); err != nil {
+		t.Fatalf("Failed to write fluff-conditionsdiseases.json: %v", err)
+	}
+
+	if err := parseConditions(context.Background(), dataDir, outDir); err != nil {
+		t.Fatalf("parseConditions() error = %v", err)
+	}
+
+	expectedFiles := []string{
+		filepath.Join("conditions", "Blinded.md"),
+		filepath.Join("diseases", "Sewer Plague.md"),
+		filepath.Join("statuses", "Surprised.md"),
+	}
+	for _, expectedFile := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(outDir, expectedFile)); os.IsNotExist(err) {
+			t.Errorf("Expected file %s does not exist", expectedFile)
+		}
+	}
+
+	blindedContent, err := os.ReadFile(filepath.Join(outDir, "conditions", "Blinded.md"))
+	if err != nil {
+		t.Fatalf("Failed to read Blinded.md: %v", err)
+	}
+	if !strings.Contains(string(blindedContent), "## Lore\n\nDarkness is everywhere.") {
+		t.Errorf("Blinded.md missing fluff, got %s", blindedContent)
+	}
+}
@@ -0,0 +1,220 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// defaultPantheon is the pantheon 5etools assumes when a deity reference doesn't name one
+const defaultPantheon = "Forgotten Realms"
+
+// DeityFile represents the structure of the deities.json file
+type DeityFile struct {
+	Deity []Deity `json:"deity"`
+}
+
+// Deity represents a single deity entry
+type Deity struct {
+	Name      string        `json:"name"`
+	Source    string        `json:"source"`
+	Page      int           `json:"page,omitempty"`
+	Pantheon  string        `json:"pantheon"`
+	Title     string        `json:"title,omitempty"`
+	Category  string        `json:"category,omitempty"`
+	Alignment []string      `json:"alignment,omitempty"`
+	Domains   []string      `json:"domains,omitempty"`
+	Province  string        `json:"province,omitempty"`
+	Symbol    string        `json:"symbol,omitempty"`
+	AltNames  []string      `json:"altNames,omitempty"`
+	Entries   []interface{} `json:"entries,omitempty"`
+}
+
+// parseDeities parses the deity data from the specified directory and writes a note per deity,
+// grouped into a folder per pantheon with an index note for each pantheon. A deity printed in several books,
+// such as Tymora in the PHB and SCAG, is written from the most recently published one.
+func parseDeities(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Read and parse the deity file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "deities.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read deity file: %w", err)
+	}
+
+	var deityFile DeityFile
+	if err := json.Unmarshal(fileData, &deityFile); err != nil {
+		return fmt.Errorf("failed to parse deity file: %w", err)
+	}
+
+	published, err := readSourcePublished(dataDirectory)
+	if err != nil {
+		return err
+	}
+
+	// Group deities by pantheon
+	pantheons := make(map[string][]Deity)
+	for _, deity := range newestDeities(deityFile.Deity, published) {
+		pantheons[deity.Pantheon] = append(pantheons[deity.Pantheon], deity)
+	}
+
+	for pantheon, deities := range pantheons {
+		// Create output directory if it doesn't exist
+		outDir := filepath.Join(outDirectory, "deities", safeFileName(pantheon))
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		// Process each deity
+		for _, deity := range deities {
+			mdContent, err := deityToMarkdown(deity)
+			if err != nil {
+				return fmt.Errorf("failed to convert deity to markdown: %w", err)
+			}
+
+			if err := writeNote(outDir, getDeityNoteName(deity.Name, deity.Pantheon), mdContent); err != nil {
+				return err
+			}
+		}
+
+		// Write the pantheon index
+		mdContent, err := pantheonToMarkdown(pantheon, deities)
+		if err != nil {
+			return fmt.Errorf("failed to convert pantheon to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, getPantheonNoteName(pantheon), mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deityToMarkdown converts a deity to Markdown format
+func deityToMarkdown(deity Deity) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", deity.Name))
+
+	// Title and category, e.g. "*God of war, Greater deity*"
+	var subtitle []string
+	if deity.Title != "" {
+		subtitle = append(subtitle, titleCase(deity.Title))
+	}
+	if deity.Category != "" {
+		subtitle = append(subtitle, deity.Category)
+	}
+	if len(subtitle) > 0 {
+		md.WriteString(fmt.Sprintf("*%s*\n\n", strings.Join(subtitle, ", ")))
+	}
+
+	md.WriteString(fmt.Sprintf("**Pantheon:** %s\n\n", linkTo(getPantheonNoteName(deity.Pantheon))))
+
+	if alignment := getDeityAlignment(deity.Alignment); alignment != "" {
+		md.WriteString(fmt.Sprintf("**Alignment:** %s\n\n", alignment))
+	}
+
+	if len(deity.Domains) > 0 {
+		md.WriteString(fmt.Sprintf("**Domains:** %s\n\n", strings.Join(deity.Domains, ", ")))
+	}
+
+	if deity.Province != "" {
+		md.WriteString(fmt.Sprintf("**Province:** %s\n\n", processSpecialFormatting(deity.Province)))
+	}
+
+	if deity.Symbol != "" {
+		md.WriteString(fmt.Sprintf("**Symbol:** %s\n\n", processSpecialFormatting(deity.Symbol)))
+	}
+
+	if len(deity.AltNames) > 0 {
+		md.WriteString(fmt.Sprintf("**Alternate Names:** %s\n\n", strings.Join(deity.AltNames, ", ")))
+	}
+
+	// Description
+	entriesToMarkdown(&md, deity.Entries, 2)
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", deity.Source))
+	if deity.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", deity.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// pantheonToMarkdown converts a pantheon and its deities to an index note with a table of linked deities
+func pantheonToMarkdown(pantheon string, deities []Deity) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", getPantheonNoteName(pantheon)))
+
+	sorted := make([]Deity, len(deities))
+	copy(sorted, deities)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Name < sorted[j].Name
+	})
+
+	md.WriteString("| Deity | Alignment | Domains | Province | Symbol |\n")
+	md.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, deity := range sorted {
+		md.WriteString(fmt.Sprintf("| %s | %s | %s | %s | %s |\n",
+			tableCellToMarkdown(fmt.Sprintf("[[%s|%s]]", safeFileName(getDeityNoteName(deity.Name, deity.Pantheon)), deity.Name)),
+			getDeityAlignment(deity.Alignment),
+			strings.Join(deity.Domains, ", "),
+			tableCellToMarkdown(deity.Province),
+			tableCellToMarkdown(deity.Symbol)))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// newestDeities keeps one printing of each deity in each pantheon, preferring the most recently published source.
+// Sources without a publication date count as the oldest, and ties go to the later source abbreviation so the
+// choice doesn't depend on the order of the file.
+func newestDeities(deities []Deity, published map[string]string) []Deity {
+	var result []Deity
+	index := make(map[string]int) // The position in result of each deity name and pantheon
+	for _, deity := range deities {
+		key := strings.ToLower(getDeityNoteName(deity.Name, deity.Pantheon))
+		i, ok := index[key]
+		if !ok {
+			index[key] = len(result)
+			result = append(result, deity)
+			continue
+		}
+
+		current := result[i]
+		deityDate, currentDate := published[strings.ToUpper(deity.Source)], published[strings.ToUpper(current.Source)]
+		if deityDate > currentDate || (deityDate == currentDate && deity.Source > current.Source) {
+			result[i] = deity
+		}
+	}
+	return result
+}
+
+// getDeityNoteName returns the note name for a deity. The pantheon is included because
+// many deities, such as Tyr or Bahamut, appear in more than one pantheon.
+func getDeityNoteName(name, pantheon string) string {
+	return fmt.Sprintf("%s (%s)", name, pantheon)
+}
+
+// getPantheonNoteName returns the note name for a pantheon index
+func getPantheonNoteName(pantheon string) string {
+	return pantheon + " Pantheon"
+}
+
+// getDeityAlignment returns the full alignment of a deity, e.g. ["C", "G"] -> "chaotic good"
+func getDeityAlignment(alignment []string) string {
+	alignments := make([]string, 0, len(alignment))
+	for _, a := range alignment {
+		alignments = append(alignments, getAlignmentString(a))
+	}
+	return strings.Join(alignments, " ")
+}
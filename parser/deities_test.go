@@ -0,0 +1,154 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDeityToMarkdown(t *testing.T) {
+	deity := Deity{
+		Name:      "Tyr",
+		Source:    "PHB",
+		Page:      294,
+		Pantheon:  "Forgotten Realms",
+		Title:     "god of justice",
+		Category:  "Lesser deity",
+		Alignment: []string{"L", "G"},
+		Domains:   []string{"War"},
+		Symbol:    "Balanced scales resting on a warhammer",
+	}
+
+	md, err := deityToMarkdown(deity)
+	if err != nil {
+		t.Fatalf("deityToMarkdown() error = %v", err)
+	}
+
+	expected := "# Tyr\n\n*God of Justice, Lesser deity*\n\n" +
+		"**Pantheon:** [[Forgotten Realms Pantheon]]\n\n" +
+		"**Alignment:** lawful good\n\n" +
+		"**Domains:** War\n\n" +
+		"**Symbol:** Balanced scales resting on a warhammer\n\n" +
+		"**Source:** PHB, page 294\n"
+	if md != expected {
+		t.Errorf("deityToMarkdown() = %q, want %q", md, expected)
+	}
+}
+
+func TestPantheonToMarkdown(t *testing.T) {
+	deities := []Deity{
+		{Name: "Tyr", Pantheon: "Norse", Alignment: []string{"L", "N"}, Domains: []string{"Knowledge", "War"}, Province: "Courage and strategy", Symbol: "Sword"},
+		{Name: "Odin", Pantheon: "Norse", Alignment: []string{"N", "G"}, Domains: []string{"Knowledge", "War"}, Province: "Knowledge and war", Symbol: "Watching blue eye"},
+	}
+
+	md, err := pantheonToMarkdown("Norse", deities)
+	if err != nil {
+		t.Fatalf("pantheonToMarkdown() error = %v", err)
+	}
+
+	expected := "# Norse Pantheon\n\n" +
+		"| Deity | Alignment | Domains | Province | Symbol |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| [[Odin (Norse)\\|Odin]] | neutral good | Knowledge, War | Knowledge and war | Watching blue eye |\n" +
+		"| [[Tyr (Norse)\\|Tyr]] | lawful neutral | Knowledge, War | Courage and strategy | Sword |\n\n"
+	if md != expected {
+		t.Errorf("pantheonToMarkdown() = %q, want %q", md, expected)
+	}
+}
+
+func TestProcessDeityTag(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Worshippers of {@deity Lathander}.", "Worshippers of [[Lathander (Forgotten Realms)|Lathander]]."},
+		{"The god {@deity Tyr|Norse}.", "The god [[Tyr (Norse)|Tyr]]."},
+		{"The {@deity Bahamut|Dawn War|DMG|Platinum Dragon}.", "The [[Bahamut (Dawn War)|Platinum Dragon]]."},
+	}
+
+	for _, test := range tests {
+		result := processDeityTag(test.input)
+		if result != test.expected {
+			t.Errorf("processDeityTag(%q) = %q; want %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestParseDeities_WithMockData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "deities-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	deityFile := DeityFile{
+		Deity: []Deity{
+			{Name: "Tyr", Source: "PHB", Pantheon: "Forgotten Realms", Alignment: []string{"L", "G"}},
+			{Name: "Tyr", Source: "PHB", Pantheon: "Norse", Alignment: []string{"L", "N"}},
+		},
+	}
+	data, err := json.Marshal(deityFile)
+	if err != nil {
+		t.Fatalf("Failed to marshal deity data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "deities.json"), data, 0644); err != nil {
+		t.Fatalf("Failed to write deities.json: %v", err)
+	}
+
+	if err := parseDeities(context.Background(), dataDir, outDir); err != nil {
+		t.Fatalf("parseDeities() error = %v", err)
+	}
+
+	expectedFiles := []string{
+		filepath.Join("Forgotten Realms", "Tyr (Forgotten Realms).md"),
+		filepath.Join("Forgotten Realms", "Forgotten Realms Pantheon.md"),
+		filepath.Join("Norse", "Tyr (Norse).md"),
+		filepath.Join("Norse", "Norse Pantheon.md"),
+	}
+	for _, expectedFile := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(outDir, "deities", expectedFile)); os.IsNotExist(err) {
+			t.Errorf("Expected file %s does not exist", expectedFile)
+		}
+	}
+
+	norseContent, err := os.ReadFile(filepath.Join(outDir, "deities", "Norse", "Norse Pantheon.md"))
+	if err != nil {
+		t.Fatalf("Failed to read Norse Pantheon.md: %v", err)
+	}
+	if !strings.Contains(string(norseContent), "[[Tyr (Norse)\\|Tyr]]") {
+		t.Errorf("Norse Pantheon.md missing deity link, got %s", norseContent)
+	}
+}
+
+func TestNewestDeities(t *testing.T) {
+	deities := []Deity{
+		{Name: "Tymora", Source: "SCAG", Pantheon: "Forgotten Realms"},
+		{Name: "Tymora", Source: "PHB", Pantheon: "Forgotten Realms"},
+		{Name: "Tyr", Source: "PHB", Pantheon: "Forgotten Realms"},
+		{Name: "Tyr", Source: "PHB", Pantheon: "Norse"},
+		{Name: "Lolth", Source: "XYZ", Pantheon: "Drow"},
+		{Name: "Lolth", Source: "ABC", Pantheon: "Drow"},
+	}
+	published := map[string]string{"PHB": "2014-08-19", "SCAG": "2015-11-03"}
+
+	result := newestDeities(deities, published)
+
+	// Deities in different pantheons are kept, and undated sources fall back to the source abbreviation
+	expected := []string{"Tymora|SCAG|Forgotten Realms", "Tyr|PHB|Forgotten Realms", "Tyr|PHB|Norse", "Lolth|XYZ|Drow"}
+	var got []string
+	for _, deity := range result {
+		got = append(got, deity.Name+"|"+deity.Source+"|"+deity.Pantheon)
+	}
+	if strings.Join(got, ", ") != strings.Join(expected, ", ") {
+		t.Errorf("newestDeities() = %v, want %v", got, expected)
+	}
+}
@@ -0,0 +1,319 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// entriesToMarkdown renders a list of 5etools entries to Markdown.
+// Named sections are written as headings starting at the given depth.
+func entriesToMarkdown(md *strings.Builder, entries []interface{}, depth int) {
+	for _, entry := range entries {
+		entryToMarkdown(md, entry, depth)
+	}
+}
+
+// entryToMarkdown renders a single 5etools entry to Markdown.
+// Entries can be plain strings or objects with a "type" field.
+func entryToMarkdown(md *strings.Builder, entry interface{}, depth int) {
+	switch e := entry.(type) {
+	case string:
+		md.WriteString(processSpecialFormatting(e))
+		md.WriteString("\n\n")
+	case map[string]interface{}:
+		entryType, _ := e["type"].(string)
+		switch entryType {
+		case "entries", "section", "options", "":
+			if name, ok := e["name"].(string); ok && name != "" {
+				md.WriteString(fmt.Sprintf("%s %s\n\n", headingPrefix(depth), processSpecialFormatting(name)))
+			}
+			if entries, ok := e["entries"].([]interface{}); ok {
+				entriesToMarkdown(md, entries, depth+1)
+			}
+		case "inset", "insetReadaloud", "quote":
+			var inner strings.Builder
+			if name, ok := e["name"].(string); ok && name != "" {
+				inner.WriteString(fmt.Sprintf("**%s**\n\n", processSpecialFormatting(name)))
+			}
+			if entries, ok := e["entries"].([]interface{}); ok {
+				entriesToMarkdown(&inner, entries, depth+1)
+			}
+			if by, ok := e["by"].(string); ok && by != "" {
+				inner.WriteString(fmt.Sprintf("— %s\n\n", processSpecialFormatting(by)))
+			}
+			md.WriteString(blockquote(inner.String()))
+		case "list":
+			if items, ok := e["items"].([]interface{}); ok {
+				for _, item := range items {
+					md.WriteString("- " + listItemToMarkdown(item) + "\n")
+				}
+				md.WriteString("\n")
+			}
+		case "item", "itemSub", "inline", "inlineBlock":
+			md.WriteString(listItemToMarkdown(e))
+			md.WriteString("\n\n")
+		case "table":
+			tableToMarkdown(md, e)
+		case "abilityDc":
+			md.WriteString(fmt.Sprintf("**%s save DC** = 8 + your proficiency bonus + your %s modifier\n\n",
+				abilityEntryName(e), abilityList(e["attributes"])))
+		case "abilityAttackMod":
+			md.WriteString(fmt.Sprintf("**%s attack modifier** = your proficiency bonus + your %s modifier\n\n",
+				abilityEntryName(e), abilityList(e["attributes"])))
+		case "hr":
+			md.WriteString("---\n\n")
+		case "statblock":
+			// Statblock references point at a generated note, e.g. {"tag": "creature", "name": "Goblin"}
+			if name, ok := e["name"].(string); ok {
+				link := linkTo(name)
+				if display, ok := e["displayName"].(string); ok && display != "" {
+					link = fmt.Sprintf("[[%s|%s]]", safeFileName(name), display)
+				}
+				md.WriteString(fmt.Sprintf("**Stat Block:** %s\n\n", link))
+			}
+		case "statblockInline":
+			if data, ok := e["data"].(map[string]interface{}); ok {
+				if name, ok := data["name"].(string); ok {
+					md.WriteString(fmt.Sprintf("**Stat Block:** %s\n\n", name))
+				}
+				if entries, ok := data["entries"].([]interface{}); ok {
+					entriesToMarkdown(md, entries, depth+1)
+				}
+			}
+		default:
+			// Fall back to rendering any nested entries so no text is lost
+			if entries, ok := e["entries"].([]interface{}); ok {
+				entriesToMarkdown(md, entries, depth)
+			} else if entry, ok := e["entry"]; ok {
+				entryToMarkdown(md, entry, depth)
+			}
+		}
+	}
+}
+
+// listItemToMarkdown renders a list item or inline entry to a single line of Markdown
+func listItemToMarkdown(item interface{}) string {
+	switch i := item.(type) {
+	case string:
+		return processSpecialFormatting(i)
+	case map[string]interface{}:
+		var parts []string
+		if name, ok := i["name"].(string); ok && name != "" {
+			name = processSpecialFormatting(name)
+			if !strings.HasSuffix(name, ".") && !strings.HasSuffix(name, ":") {
+				name += "."
+			}
+			parts = append(parts, fmt.Sprintf("**%s**", name))
+		}
+		if entry, ok := i["entry"]; ok {
+			parts = append(parts, listItemToMarkdown(entry))
+		}
+		if entries, ok := i["entries"].([]interface{}); ok {
+			for _, entry := range entries {
+				parts = append(parts, listItemToMarkdown(entry))
+			}
+		}
+		if items, ok := i["items"].([]interface{}); ok {
+			for _, entry := range items {
+				parts = append(parts, listItemToMarkdown(entry))
+			}
+		}
+		if text, ok := i["text"].(string); ok {
+			parts = append(parts, processSpecialFormatting(text))
+		}
+		return strings.Join(parts, " ")
+	case float64:
+		return fmt.Sprintf("%v", i)
+	}
+	return ""
+}
+
+// tableToMarkdown renders a table entry, including its caption, to Markdown
+func tableToMarkdown(md *strings.Builder, table map[string]interface{}) {
+	if caption, ok := table["caption"].(string); ok && caption != "" {
+		md.WriteString(fmt.Sprintf("**%s**\n\n", processSpecialFormatting(caption)))
+	}
+
+	rows, _ := table["rows"].([]interface{})
+	colLabels, _ := table["colLabels"].([]interface{})
+
+	// Tables without labels still need a header row to be valid Markdown
+	columns := len(colLabels)
+	for _, row := range rows {
+		if rowArr, ok := row.([]interface{}); ok && len(rowArr) > columns {
+			columns = len(rowArr)
+		}
+	}
+	if columns == 0 {
+		return
+	}
+
+	header := make([]string, columns)
+	for i := range header {
+		if i < len(colLabels) {
+			header[i] = listItemToMarkdown(colLabels[i])
+		}
+	}
+	md.WriteString("| " + strings.Join(header, " | ") + " |\n")
+	md.WriteString("|" + strings.Repeat(" --- |", columns) + "\n")
+
+	for _, row := range rows {
+		var cells []interface{}
+		switch r := row.(type) {
+		case []interface{}:
+			cells = r
+		case map[string]interface{}:
+			// Rows can also be objects of the form {"type": "row", "row": [...]}
+			cells, _ = r["row"].([]interface{})
+		}
+
+		rendered := make([]string, columns)
+		for i, cell := range cells {
+			if i < columns {
+				rendered[i] = tableCellToMarkdown(cell)
+			}
+		}
+		md.WriteString("| " + strings.Join(rendered, " | ") + " |\n")
+	}
+	md.WriteString("\n")
+
+	if footnotes, ok := table["footnotes"].([]interface{}); ok {
+		for _, footnote := range footnotes {
+			md.WriteString(listItemToMarkdown(footnote) + "\n\n")
+		}
+	}
+}
+
+// tableCellToMarkdown renders a single table cell, escaping pipes so the table stays intact
+func tableCellToMarkdown(cell interface{}) string {
+	if c, ok := cell.(map[string]interface{}); ok {
+		if roll, ok := c["roll"].(map[string]interface{}); ok {
+			return rollToText(roll)
+		}
+	}
+	return strings.ReplaceAll(listItemToMarkdown(cell), "|", "\\|")
+}
+
+// rollToText renders the dice range of a rollable table cell, e.g. {"min": 1, "max": 5, "pad": true} -> "01–05"
+func rollToText(roll map[string]interface{}) string {
+	format := "%d"
+	if pad, ok := roll["pad"].(bool); ok && pad {
+		format = "%02d"
+	}
+	if exact, ok := roll["exact"].(float64); ok {
+		return fmt.Sprintf(format, int(exact))
+	}
+	min, _ := roll["min"].(float64)
+	max, hasMax := roll["max"].(float64)
+	if !hasMax {
+		// Open-ended ranges such as "20+" have no maximum
+		return fmt.Sprintf(format+"+", int(min))
+	}
+	return fmt.Sprintf(format+"–"+format, int(min), int(max))
+}
+
+// blockquote prefixes every line of the given Markdown with "> "
+func blockquote(content string) string {
+	lines := strings.Split(strings.TrimRight(content, "\n"), "\n")
+	for i, line := range lines {
+		if line == "" {
+			lines[i] = ">"
+		} else {
+			lines[i] = "> " + line
+		}
+	}
+	return strings.Join(lines, "\n") + "\n\n"
+}
+
+// headingPrefix returns the Markdown heading marker for the given depth, capped at six levels
+func headingPrefix(depth int) string {
+	if depth < 1 {
+		depth = 1
+	}
+	if depth > 6 {
+		depth = 6
+	}
+	return strings.Repeat("#", depth)
+}
+
+// abilityList returns the full ability names for a list of ability abbreviations
+func abilityList(attributes interface{}) string {
+	list, _ := attributes.([]interface{})
+	names := make([]string, 0, len(list))
+	for _, attribute := range list {
+		if abbreviation, ok := attribute.(string); ok {
+			names = append(names, getAbilityName(abbreviation))
+		}
+	}
+	return joinWithOr(names)
+}
+
+// abilityEntryName returns the name of an abilityDc or abilityAttackMod entry, e.g. "Spell" in "Spell save DC",
+// falling back to "Spell" when the entry has no name
+func abilityEntryName(e map[string]interface{}) string {
+	if name, ok := e["name"].(string); ok && name != "" {
+		return name
+	}
+	return "Spell"
+}
+
+// getAbilityName returns the full name of an ability from its abbreviation
+func getAbilityName(ability string) string {
+	switch ability {
+	case "str":
+		return "Strength"
+	case "dex":
+		return "Dexterity"
+	case "con":
+		return "Constitution"
+	case "int":
+		return "Intelligence"
+	case "wis":
+		return "Wisdom"
+	case "cha":
+		return "Charisma"
+	default:
+		return ability
+	}
+}
+
+// joinWithOr joins values as "a, b, or c"
+func joinWithOr(values []string) string {
+	return joinWithConjunction(values, "or")
+}
+
+// joinWithAnd joins values as "a, b, and c"
+func joinWithAnd(values []string) string {
+	return joinWithConjunction(values, "and")
+}
+
+// joinWithConjunction joins values as a natural language list using the given conjunction
+func joinWithConjunction(values []string, conjunction string) string {
+	switch len(values) {
+	case 0:
+		return ""
+	case 1:
+		return values[0]
+	case 2:
+		return values[0] + " " + conjunction + " " + values[1]
+	default:
+		return strings.Join(values[:len(values)-1], ", ") + ", " + conjunction + " " + values[len(values)-1]
+	}
+}
+
+// getOrdinal returns the ordinal form of a number (e.g., 1 -> "1st", 22 -> "22nd")
+func getOrdinal(n int) string {
+	if n%100 >= 11 && n%100 <= 13 {
+		return fmt.Sprintf("%dth", n)
+	}
+	switch n % 10 {
+	case 1:
+		return fmt.Sprintf("%dst", n)
+	case 2:
+		return fmt.Sprintf("%dnd", n)
+	case 3:
+		return fmt.Sprintf("%drd", n)
+	default:
+		return fmt.Sprintf("%dth", n)
+	}
+}
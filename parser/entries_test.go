@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEntriesToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []interface{}
+		expected string
+	}{
+		{
+			name:     "Plain strings",
+			entries:  []interface{}{"First paragraph.", "Second with {@damage 2d6} damage."},
+			expected: "First paragraph.\n\nSecond with 2d6 damage.\n\n",
+		},
+		{
+			name: "Nested named sections",
+			entries: []interface{}{
+				map[string]interface{}{
+					"type": "entries",
+					"name": "Outer",
+					"entries": []interface{}{
+						"Outer text.",
+						map[string]interface{}{
+							"type":    "entries",
+							"name":    "Inner",
+							"entries": []interface{}{"Inner text."},
+						},
+					},
+				},
+			},
+			expected: "## Outer\n\nOuter text.\n\n### Inner\n\nInner text.\n\n",
+		},
+		{
+			name: "Inset",
+			entries: []interface{}{
+				map[string]interface{}{
+					"type":    "inset",
+					"name":    "Variant",
+					"entries": []interface{}{"Line one.", "Line two."},
+				},
+			},
+			expected: "> **Variant**\n>\n> Line one.\n>\n> Line two.\n\n",
+		},
+		{
+			name: "List with named items",
+			entries: []interface{}{
+				map[string]interface{}{
+					"type": "list",
+					"items": []interface{}{
+						"Plain item",
+						map[string]interface{}{"type": "item", "name": "Named", "entry": "Item text."},
+					},
+				},
+			},
+			expected: "- Plain item\n- **Named.** Item text.\n\n",
+		},
+		{
+			name: "Table with caption",
+			entries: []interface{}{
+				map[string]interface{}{
+					"type":      "table",
+					"caption":   "Trinkets",
+					"colLabels": []interface{}{"d4", "Trinket"},
+					"rows": []interface{}{
+						[]interface{}{"1", "A {@item dagger}"},
+						[]interface{}{"2-4", "A pipe | bowl"},
+					},
+				},
+			},
+			expected: "**Trinkets**\n\n| d4 | Trinket |\n| --- | --- |\n| 1 | A dagger |\n| 2-4 | A pipe \\| bowl |\n\n",
+		},
+		{
+			name: "Table with roll cells",
+			entries: []interface{}{
+				map[string]interface{}{
+					"type":      "table",
+					"colLabels": []interface{}{"d100", "Effect"},
+					"rows": []interface{}{
+						[]interface{}{
+							map[string]interface{}{"type": "cell", "roll": map[string]interface{}{"min": float64(1), "max": float64(5), "pad": true}},
+							"Nothing happens.",
+						},
+						[]interface{}{
+							map[string]interface{}{"type": "cell", "roll": map[string]interface{}{"exact": float64(6), "pad": true}},
+							"You sneeze.",
+						},
+					},
+				},
+			},
+			expected: "| d100 | Effect |\n| --- | --- |\n| 01–05 | Nothing happens. |\n| 06 | You sneeze. |\n\n",
+		},
+		{
+			name: "Ability DC and attack modifier",
+			entries: []interface{}{
+				map[string]interface{}{"type": "abilityDc", "name": "Ki", "attributes": []interface{}{"wis"}},
+				map[string]interface{}{"type": "abilityAttackMod", "attributes": []interface{}{"int", "cha"}},
+			},
+			expected: "**Ki save DC** = 8 + your proficiency bonus + your Wisdom modifier\n\n" +
+				"**Spell attack modifier** = your proficiency bonus + your Intelligence or Charisma modifier\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var md strings.Builder
+			entriesToMarkdown(&md, tt.entries, 2)
+			if md.String() != tt.expected {
+				t.Errorf("entriesToMarkdown() = %q, want %q", md.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetOrdinal(t *testing.T) {
+	tests := []struct {
+		n        int
+		expected string
+	}{
+		{1, "1st"},
+		{2, "2nd"},
+		{3, "3rd"},
+		{4, "4th"},
+		{11, "11th"},
+		{12, "12th"},
+		{13, "13th"},
+		{21, "21st"},
+		{22, "22nd"},
+	}
+
+	for _, test := range tests {
+		result := getOrdinal(test.n)
+		if result != test.expected {
+			t.Errorf("getOrdinal(%d) = %s; want %s", test.n, result, test.expected)
+		}
+	}
+}
@@ -0,0 +1,635 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// FeatFile represents the structure of the feats.json file
+type FeatFile struct {
+	Feat []Feat `json:"feat"`
+}
+
+// Feat represents a single feat entry
+type Feat struct {
+	Name             string                   `json:"name"`
+	Source           string                   `json:"source"`
+	Page             int                      `json:"page,omitempty"`
+	Category         string                   `json:"category,omitempty"`
+	Prerequisite     []map[string]interface{} `json:"prerequisite,omitempty"`
+	Ability          []map[string]interface{} `json:"ability,omitempty"`
+	AdditionalSpells []map[string]interface{} `json:"additionalSpells,omitempty"`
+	Repeatable       bool                     `json:"repeatable,omitempty"`
+	Entries          []interface{}            `json:"entries,omitempty"`
+}
+
+// OptionalFeatureFile represents the structure of the optionalfeatures.json file
+type OptionalFeatureFile struct {
+	OptionalFeature []OptionalFeature `json:"optionalfeature"`
+}
+
+// OptionalFeature represents a single optional feature such as an Eldritch Invocation or Metamagic option
+type OptionalFeature struct {
+	Name             string                   `json:"name"`
+	Source           string                   `json:"source"`
+	Page             int                      `json:"page,omitempty"`
+	FeatureType      []string                 `json:"featureType"`
+	Prerequisite     []map[string]interface{} `json:"prerequisite,omitempty"`
+	Ability          []map[string]interface{} `json:"ability,omitempty"`
+	AdditionalSpells []map[string]interface{} `json:"additionalSpells,omitempty"`
+	Consumes         map[string]interface{}   `json:"consumes,omitempty"`
+	Entries          []interface{}            `json:"entries,omitempty"`
+}
+
+// parseFeats parses the feat data from the specified directory and writes it to the output directory.
+func parseFeats(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Create output directory if it doesn't exist
+	outDir := filepath.Join(outDirectory, "feats")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Read and parse the feat file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "feats.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read feat file: %w", err)
+	}
+
+	var featFile FeatFile
+	if err := json.Unmarshal(fileData, &featFile); err != nil {
+		return fmt.Errorf("failed to parse feat file: %w", err)
+	}
+
+	// Process each feat
+	for _, feat := range featFile.Feat {
+		mdContent, err := featToMarkdown(feat)
+		if err != nil {
+			return fmt.Errorf("failed to convert feat to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, feat.Name, mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseOptionalFeatures parses the optional feature data from the specified directory and writes it to the
+// output directory, grouping the notes into a folder per feature type.
+func parseOptionalFeatures(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Read and parse the optional feature file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "optionalfeatures.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read optional feature file: %w", err)
+	}
+
+	var optionalFeatureFile OptionalFeatureFile
+	if err := json.Unmarshal(fileData, &optionalFeatureFile); err != nil {
+		return fmt.Errorf("failed to parse optional feature file: %w", err)
+	}
+
+	// Process each optional feature
+	for _, feature := range optionalFeatureFile.OptionalFeature {
+		mdContent, err := optionalFeatureToMarkdown(feature)
+		if err != nil {
+			return fmt.Errorf("failed to convert optional feature to markdown: %w", err)
+		}
+
+		// Group notes by the first feature type, e.g. "optional-features/Eldritch Invocation"
+		group := "Other"
+		if len(feature.FeatureType) > 0 {
+			group = getFeatureTypeGroup(feature.FeatureType[0])
+		}
+
+		outDir := filepath.Join(outDirectory, "optional-features", safeFileName(group))
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		if err := writeNote(outDir, feature.Name, mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// featToMarkdown converts a feat to Markdown format
+func featToMarkdown(feat Feat) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", feat.Name))
+
+	// Category
+	if feat.Category != "" {
+		md.WriteString(fmt.Sprintf("*%s*\n\n", getFeatCategoryName(feat.Category)))
+	}
+
+	// Prerequisite
+	if prerequisite := formatPrerequisites(feat.Prerequisite); prerequisite != "" {
+		md.WriteString(fmt.Sprintf("**Prerequisite:** %s\n\n", prerequisite))
+	}
+
+	if feat.Repeatable {
+		md.WriteString("**Repeatable:** Yes\n\n")
+	}
+
+	// Ability Score Increase
+	if ability := formatAbilityIncreases(feat.Ability); ability != "" {
+		md.WriteString(fmt.Sprintf("**Ability Score Increase:** %s\n\n", ability))
+	}
+
+	// Description
+	entriesToMarkdown(&md, feat.Entries, 2)
+
+	// Granted spells
+	if spells := formatAdditionalSpells(feat.AdditionalSpells); spells != "" {
+		md.WriteString(fmt.Sprintf("**Spells:** %s\n\n", spells))
+	}
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", feat.Source))
+	if feat.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", feat.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// optionalFeatureToMarkdown converts an optional feature to Markdown format
+func optionalFeatureToMarkdown(feature OptionalFeature) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", feature.Name))
+
+	// Feature types
+	if len(feature.FeatureType) > 0 {
+		featureTypes := make([]string, 0, len(feature.FeatureType))
+		for _, featureType := range feature.FeatureType {
+			featureTypes = append(featureTypes, getFeatureTypeName(featureType))
+		}
+		md.WriteString(fmt.Sprintf("*%s*\n\n", strings.Join(featureTypes, ", ")))
+	}
+
+	// Prerequisite
+	if prerequisite := formatPrerequisites(feature.Prerequisite); prerequisite != "" {
+		md.WriteString(fmt.Sprintf("**Prerequisite:** %s\n\n", prerequisite))
+	}
+
+	// Ability Score Increase
+	if ability := formatAbilityIncreases(feature.Ability); ability != "" {
+		md.WriteString(fmt.Sprintf("**Ability Score Increase:** %s\n\n", ability))
+	}
+
+	// Resource cost, e.g. sorcery points for Metamagic
+	if feature.Consumes != nil {
+		if name, ok := feature.Consumes["name"].(string); ok {
+			amount := 1
+			if a, ok := feature.Consumes["amount"].(float64); ok {
+				amount = int(a)
+			}
+			md.WriteString(fmt.Sprintf("**Cost:** %d %s\n\n", amount, name))
+		}
+	}
+
+	// Description
+	entriesToMarkdown(&md, feature.Entries, 2)
+
+	// Granted spells
+	if spells := formatAdditionalSpells(feature.AdditionalSpells); spells != "" {
+		md.WriteString(fmt.Sprintf("**Spells:** %s\n\n", spells))
+	}
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", feature.Source))
+	if feature.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", feature.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// formatPrerequisites converts a list of prerequisites to a human-readable string.
+// Each object in the list is an alternative; the fields within an object must all be met.
+func formatPrerequisites(prerequisites []map[string]interface{}) string {
+	alternatives := make([]string, 0, len(prerequisites))
+	for _, prerequisite := range prerequisites {
+		if text := formatPrerequisite(prerequisite); text != "" {
+			alternatives = append(alternatives, text)
+		}
+	}
+	return strings.Join(alternatives, "; or ")
+}
+
+// formatPrerequisite converts a single prerequisite object to a human-readable string
+func formatPrerequisite(prerequisite map[string]interface{}) string {
+	var parts []string
+
+	// Level can be a number or an object with a class and subclass
+	switch level := prerequisite["level"].(type) {
+	case float64:
+		parts = append(parts, fmt.Sprintf("%s level", getOrdinal(int(level))))
+	case map[string]interface{}:
+		levelNumber, _ := level["level"].(float64)
+		text := fmt.Sprintf("%s level", getOrdinal(int(levelNumber)))
+		if class, ok := level["class"].(map[string]interface{}); ok {
+			if className, ok := class["name"].(string); ok {
+				text = fmt.Sprintf("%s-level %s", getOrdinal(int(levelNumber)), className)
+			}
+		}
+		if subclass, ok := level["subclass"].(map[string]interface{}); ok {
+			if subclassName, ok := subclass["name"].(string); ok {
+				text += fmt.Sprintf(" (%s)", subclassName)
+			}
+		}
+		parts = append(parts, text)
+	}
+
+	if races, ok := prerequisite["race"].([]interface{}); ok {
+		raceNames := make([]string, 0, len(races))
+		for _, race := range races {
+			raceMap, ok := race.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			if displayEntry, ok := raceMap["displayEntry"].(string); ok {
+				raceNames = append(raceNames, processSpecialFormatting(displayEntry))
+				continue
+			}
+			name, _ := raceMap["name"].(string)
+			raceName := titleCase(name)
+			if subrace, ok := raceMap["subrace"].(string); ok {
+				raceName += fmt.Sprintf(" (%s)", titleCase(subrace))
+			}
+			raceNames = append(raceNames, raceName)
+		}
+		if len(raceNames) > 0 {
+			parts = append(parts, joinWithOr(raceNames))
+		}
+	}
+
+	// Each ability object is an alternative, and the scores within one object are all required, e.g.
+	// [{"str": 13}, {"dex": 13}] is "Strength 13 or higher or Dexterity 13 or higher"
+	if abilities, ok := prerequisite["ability"].([]interface{}); ok {
+		abilityTexts := make([]string, 0, len(abilities))
+		for _, ability := range abilities {
+			abilityMap, ok := ability.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			var scores []string
+			for _, abbreviation := range []string{"str", "dex", "con", "int", "wis", "cha"} {
+				if score, ok := abilityMap[abbreviation].(float64); ok {
+					scores = append(scores, fmt.Sprintf("%s %d or higher", getAbilityName(abbreviation), int(score)))
+				}
+			}
+			if len(scores) > 0 {
+				abilityTexts = append(abilityTexts, joinWithAnd(scores))
+			}
+		}
+		if len(abilityTexts) > 0 {
+			parts = append(parts, joinWithOr(abilityTexts))
+		}
+	}
+
+	for _, key := range []string{"spellcasting", "spellcasting2020", "spellcastingFeature", "spellcastingPrepared"} {
+		if spellcasting, ok := prerequisite[key].(bool); ok && spellcasting {
+			parts = append(parts, "The ability to cast at least one spell")
+			break
+		}
+	}
+
+	if psionics, ok := prerequisite["psionics"].(bool); ok && psionics {
+		parts = append(parts, "Psionic Talent feature or Psionic Initiate feat")
+	}
+
+	if proficiencies, ok := prerequisite["proficiency"].([]interface{}); ok {
+		for _, proficiency := range proficiencies {
+			if proficiencyMap, ok := proficiency.(map[string]interface{}); ok {
+				if armor, ok := proficiencyMap["armor"].(string); ok {
+					parts = append(parts, fmt.Sprintf("Proficiency with %s armor", armor))
+				}
+				if weapon, ok := proficiencyMap["weapon"].(string); ok {
+					parts = append(parts, fmt.Sprintf("Proficiency with a %s weapon", weapon))
+				}
+				if weaponGroup, ok := proficiencyMap["weaponGroup"].(string); ok {
+					parts = append(parts, fmt.Sprintf("Proficiency with %s weapons", weaponGroup))
+				}
+			}
+		}
+	}
+
+	if backgrounds, ok := prerequisite["background"].([]interface{}); ok {
+		backgroundNames := make([]string, 0, len(backgrounds))
+		for _, background := range backgrounds {
+			if backgroundMap, ok := background.(map[string]interface{}); ok {
+				if name, ok := backgroundMap["name"].(string); ok {
+					backgroundNames = append(backgroundNames, titleCase(name))
+				}
+			}
+		}
+		if len(backgroundNames) > 0 {
+			parts = append(parts, joinWithOr(backgroundNames)+" background")
+		}
+	}
+
+	if feats := stringList(prerequisite["feat"]); len(feats) > 0 {
+		links := make([]string, 0, len(feats))
+		for _, feat := range feats {
+			links = append(links, linkToReference(feat))
+		}
+		parts = append(parts, joinWithOr(links)+" feat")
+	}
+
+	if features := stringList(prerequisite["feature"]); len(features) > 0 {
+		parts = append(parts, joinWithOr(features)+" feature")
+	}
+
+	if optionalFeatures := stringList(prerequisite["optionalfeature"]); len(optionalFeatures) > 0 {
+		links := make([]string, 0, len(optionalFeatures))
+		for _, optionalFeature := range optionalFeatures {
+			links = append(links, linkToReference(optionalFeature))
+		}
+		parts = append(parts, joinWithOr(links))
+	}
+
+	if spells := stringList(prerequisite["spell"]); len(spells) > 0 {
+		spellTexts := make([]string, 0, len(spells))
+		for _, spell := range spells {
+			if strings.HasSuffix(spell, "#c") {
+				spellTexts = append(spellTexts, linkToReference(spell)+" cantrip")
+			} else {
+				spellTexts = append(spellTexts, linkToReference(spell)+" spell")
+			}
+		}
+		parts = append(parts, joinWithOr(spellTexts))
+	}
+
+	if pact, ok := prerequisite["pact"].(string); ok {
+		parts = append(parts, fmt.Sprintf("Pact of the %s feature", pact))
+	}
+
+	if patron, ok := prerequisite["patron"].(string); ok {
+		parts = append(parts, fmt.Sprintf("%s patron", patron))
+	}
+
+	if campaigns := stringList(prerequisite["campaign"]); len(campaigns) > 0 {
+		parts = append(parts, joinWithOr(campaigns)+" Campaign")
+	}
+
+	if other, ok := prerequisite["other"].(string); ok {
+		parts = append(parts, processSpecialFormatting(other))
+	}
+
+	if otherSummary, ok := prerequisite["otherSummary"].(map[string]interface{}); ok {
+		if entry, ok := otherSummary["entry"].(string); ok {
+			parts = append(parts, processSpecialFormatting(entry))
+		}
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+// formatAbilityIncreases converts the ability score increases of a feat to a human-readable string
+func formatAbilityIncreases(abilities []map[string]interface{}) string {
+	alternatives := make([]string, 0, len(abilities))
+	for _, ability := range abilities {
+		var parts []string
+		for _, abbreviation := range []string{"str", "dex", "con", "int", "wis", "cha"} {
+			if amount, ok := ability[abbreviation].(float64); ok {
+				parts = append(parts, fmt.Sprintf("%s %+d", getAbilityName(abbreviation), int(amount)))
+			}
+		}
+
+		if choose, ok := ability["choose"].(map[string]interface{}); ok {
+			from := stringList(choose["from"])
+			abilityNames := make([]string, 0, len(from))
+			for _, abbreviation := range from {
+				abilityNames = append(abilityNames, getAbilityName(abbreviation))
+			}
+
+			amount := 1
+			if a, ok := choose["amount"].(float64); ok {
+				amount = int(a)
+			}
+			count := 1
+			if c, ok := choose["count"].(float64); ok {
+				count = int(c)
+			}
+
+			countText := pluralize(count, "one", strconv.Itoa(count))
+			if len(abilityNames) == 6 {
+				parts = append(parts, fmt.Sprintf("%s %s of your choice %+d", countText, pluralize(count, "ability score", "ability scores"), amount))
+			} else {
+				parts = append(parts, fmt.Sprintf("%s of %s %+d", countText, joinWithOr(abilityNames), amount))
+			}
+		}
+
+		if len(parts) > 0 {
+			alternatives = append(alternatives, strings.Join(parts, ", "))
+		}
+	}
+	return strings.Join(alternatives, "; or ")
+}
+
+// formatAdditionalSpells converts the spells granted by a feat or feature to a list of spell links
+func formatAdditionalSpells(additionalSpells []map[string]interface{}) string {
+	seen := make(map[string]bool)
+	var spells []string
+	for _, additional := range additionalSpells {
+		for _, key := range []string{"innate", "known", "prepared", "expanded"} {
+			for _, spell := range collectSpellReferences(additional[key]) {
+				if !seen[spell] {
+					seen[spell] = true
+					spells = append(spells, spell)
+				}
+			}
+		}
+	}
+	return strings.Join(spells, ", ")
+}
+
+// collectSpellReferences walks the nested additionalSpells structure and returns every spell it grants.
+// Spell choices such as {"choose": "level=1|school=D;E"} are described instead of linked.
+func collectSpellReferences(value interface{}) []string {
+	var spells []string
+	switch v := value.(type) {
+	case string:
+		spells = append(spells, linkToReference(v))
+	case []interface{}:
+		for _, item := range v {
+			spells = append(spells, collectSpellReferences(item)...)
+		}
+	case map[string]interface{}:
+		if choose, ok := v["choose"].(string); ok {
+			spells = append(spells, fmt.Sprintf("one spell of your choice (%s)", formatSpellFilter(choose)))
+			return spells
+		}
+		if choose, ok := v["choose"].(map[string]interface{}); ok {
+			from := stringList(choose["from"])
+			links := make([]string, 0, len(from))
+			for _, spell := range from {
+				links = append(links, linkToReference(spell))
+			}
+			spells = append(spells, "one of "+joinWithOr(links))
+			return spells
+		}
+
+		// Walk keys in a stable order, e.g. "_", "1", "3", "daily", "rest"
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			spells = append(spells, collectSpellReferences(v[key])...)
+		}
+	}
+	return spells
+}
+
+// formatSpellFilter converts a 5etools spell filter such as "level=1|class=Wizard" to readable text
+func formatSpellFilter(filter string) string {
+	var parts []string
+	for _, part := range strings.Split(filter, "|") {
+		key, value, found := strings.Cut(part, "=")
+		if !found {
+			continue
+		}
+		values := strings.Split(value, ";")
+		switch strings.ToLower(key) {
+		case "level":
+			levels := make([]string, 0, len(values))
+			for _, level := range values {
+				if level == "0" {
+					levels = append(levels, "cantrip")
+				} else {
+					n, _ := strconv.Atoi(level)
+					levels = append(levels, getOrdinal(n)+"-level")
+				}
+			}
+			parts = append(parts, joinWithOr(levels))
+		case "school":
+			schools := make([]string, 0, len(values))
+			for _, school := range values {
+				schools = append(schools, getSchoolName(school))
+			}
+			parts = append(parts, joinWithOr(schools))
+		case "class":
+			parts = append(parts, joinWithOr(values)+" spell list")
+		default:
+			parts = append(parts, joinWithOr(values))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// getFeatCategoryName returns the full name of a feat category
+func getFeatCategoryName(category string) string {
+	switch category {
+	case "O":
+		return "Origin Feat"
+	case "G":
+		return "General Feat"
+	case "FS":
+		return "Fighting Style Feat"
+	case "EB":
+		return "Epic Boon Feat"
+	case "D":
+		return "Dragonmark Feat"
+	default:
+		return category
+	}
+}
+
+// getFeatureTypeName returns the full name of an optional feature type, e.g. "FS:F" -> "Fighting Style (Fighter)"
+func getFeatureTypeName(featureType string) string {
+	group := getFeatureTypeGroup(featureType)
+	switch featureType {
+	case "FS:F":
+		return group + " (Fighter)"
+	case "FS:B":
+		return group + " (Bard)"
+	case "FS:P":
+		return group + " (Paladin)"
+	case "FS:R":
+		return group + " (Ranger)"
+	case "MV:B":
+		return group + " (Battle Master)"
+	case "MV:C2-UA":
+		return group + " (Cavalier V2, UA)"
+	case "AS:V1-UA":
+		return group + " (V1, UA)"
+	case "AS:V2-UA":
+		return group + " (V2, UA)"
+	default:
+		return group
+	}
+}
+
+// getFeatureTypeGroup returns the name of the group an optional feature type belongs to,
+// ignoring any class or version qualifier after the colon
+func getFeatureTypeGroup(featureType string) string {
+	base, _, _ := strings.Cut(featureType, ":")
+	switch base {
+	case "AI":
+		return "Artificer Infusion"
+	case "AF":
+		return "Alchemical Formula"
+	case "AS":
+		return "Arcane Shot"
+	case "ED":
+		return "Elemental Discipline"
+	case "EI":
+		return "Eldritch Invocation"
+	case "FS":
+		return "Fighting Style"
+	case "MM":
+		return "Metamagic"
+	case "MV":
+		return "Maneuver"
+	case "OR":
+		return "Onomancy Resonant"
+	case "OTH":
+		return "Other"
+	case "PB":
+		return "Pact Boon"
+	case "RN":
+		return "Rune Knight Rune"
+	case "TT":
+		return "Traveler's Tattoo"
+	default:
+		return featureType
+	}
+}
+
+// stringList converts a JSON array of strings to a string slice, skipping any non-string values
+func stringList(value interface{}) []string {
+	list, _ := value.([]interface{})
+	result := make([]string, 0, len(list))
+	for _, item := range list {
+		if str, ok := item.(string); ok {
+			result = append(result, str)
+		}
+	}
+	return result
+}
+
+// pluralize returns the singular form when count is one and the plural form otherwise
+func pluralize(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
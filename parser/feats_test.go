@@ -0,0 +1,286 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestFormatPrerequisites(t *testing.T) {
+	tests := []struct {
+		name          string
+		prerequisites []map[string]interface{}
+		expected      string
+	}{
+		{
+			name: "Level and ability",
+			prerequisites: []map[string]interface{}{
+				{
+					"level":   float64(4),
+					"ability": []interface{}{map[string]interface{}{"str": float64(13)}},
+				},
+			},
+			expected: "4th level, Strength 13 or higher",
+		},
+		{
+			name: "Ability alternatives",
+			prerequisites: []map[string]interface{}{
+				{
+					"ability": []interface{}{
+						map[string]interface{}{"str": float64(13)},
+						map[string]interface{}{"dex": float64(13)},
+					},
+				},
+			},
+			expected: "Strength 13 or higher or Dexterity 13 or higher",
+		},
+		{
+			name: "Abilities required together",
+			prerequisites: []map[string]interface{}{
+				{
+					"ability": []interface{}{
+						map[string]interface{}{"int": float64(13), "wis": float64(13)},
+					},
+				},
+			},
+			expected: "Intelligence 13 or higher and Wisdom 13 or higher",
+		},
+		{
+			name: "Race alternatives",
+			prerequisites: []map[string]interface{}{
+				{
+					"race": []interface{}{
+						map[string]interface{}{"name": "elf"},
+						map[string]interface{}{"name": "half-elf"},
+					},
+				},
+			},
+			expected: "Elf or Half-Elf",
+		},
+		{
+			name: "Spellcasting",
+			prerequisites: []map[string]interface{}{
+				{"spellcasting2020": true},
+			},
+			expected: "The ability to cast at least one spell",
+		},
+		{
+			name: "Warlock level, pact and cantrip",
+			prerequisites: []map[string]interface{}{
+				{
+					"level": map[string]interface{}{
+						"level": float64(5),
+						"class": map[string]interface{}{"name": "Warlock"},
+					},
+					"pact":  "Blade",
+					"spell": []interface{}{"eldritch blast#c"},
+				},
+			},
+			expected: "5th-level Warlock, [[Eldritch Blast]] cantrip, Pact of the Blade feature",
+		},
+		{
+			name: "Feat and alternative",
+			prerequisites: []map[string]interface{}{
+				{"feat": []interface{}{"great weapon master|xphb"}},
+				{"other": "Proficiency with {@skill Athletics}"},
+			},
+			expected: "[[Great Weapon Master]] feat; or Proficiency with [[Athletics]]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatPrerequisites(tt.prerequisites)
+			if result != tt.expected {
+				t.Errorf("formatPrerequisites() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatAbilityIncreases(t *testing.T) {
+	tests := []struct {
+		name      string
+		abilities []map[string]interface{}
+		expected  string
+	}{
+		{
+			name:      "Fixed increase",
+			abilities: []map[string]interface{}{{"con": float64(1)}},
+			expected:  "Constitution +1",
+		},
+		{
+			name: "Choice of abilities",
+			abilities: []map[string]interface{}{
+				{"choose": map[string]interface{}{"from": []interface{}{"str", "dex"}, "amount": float64(1)}},
+			},
+			expected: "one of Strength or Dexterity +1",
+		},
+		{
+			name: "Any ability",
+			abilities: []map[string]interface{}{
+				{"choose": map[string]interface{}{"from": []interface{}{"str", "dex", "con", "int", "wis", "cha"}}},
+			},
+			expected: "one ability score of your choice +1",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatAbilityIncreases(tt.abilities)
+			if result != tt.expected {
+				t.Errorf("formatAbilityIncreases() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFeatToMarkdown(t *testing.T) {
+	feat := Feat{
+		Name:     "Fey Touched",
+		Source:   "TCE",
+		Page:     79,
+		Category: "G",
+		Ability: []map[string]interface{}{
+			{"choose": map[string]interface{}{"from": []interface{}{"int", "wis", "cha"}, "amount": float64(1)}},
+		},
+		AdditionalSpells: []map[string]interface{}{
+			{
+				"innate": map[string]interface{}{
+					"_": map[string]interface{}{
+						"daily": map[string]interface{}{"1": []interface{}{"misty step"}},
+					},
+				},
+				"known": map[string]interface{}{
+					"_": []interface{}{
+						map[string]interface{}{"choose": "level=1|school=D;E"},
+					},
+				},
+			},
+		},
+		Entries: []interface{}{
+			"Your exposure to the Feywild's magic has changed you, granting you the following benefits:",
+			map[string]interface{}{
+				"type": "list",
+				"items": []interface{}{
+					"You learn the {@spell misty step} spell.",
+				},
+			},
+		},
+	}
+
+	md, err := featToMarkdown(feat)
+	if err != nil {
+		t.Fatalf("featToMarkdown() error = %v", err)
+	}
+
+	expectedElements := []string{
+		"# Fey Touched\n\n",
+		"*General Feat*\n\n",
+		"**Ability Score Increase:** one of Intelligence, Wisdom, or Charisma +1\n\n",
+		"Your exposure to the Feywild's magic has changed you, granting you the following benefits:\n\n",
+		"- You learn the misty step spell.\n\n",
+		"**Spells:** [[Misty Step]], one spell of your choice (1st-level, Divination or Enchantment)\n\n",
+		"**Source:** TCE, page 79\n",
+	}
+
+	for _, expected := range expectedElements {
+		if !strings.Contains(md, expected) {
+			t.Errorf("featToMarkdown() output missing expected element: %q\n%s", expected, md)
+		}
+	}
+}
+
+func TestOptionalFeatureToMarkdown(t *testing.T) {
+	feature := OptionalFeature{
+		Name:        "Quickened Spell",
+		Source:      "PHB",
+		Page:        102,
+		FeatureType: []string{"MM"},
+		Consumes:    map[string]interface{}{"name": "Sorcery Point", "amount": float64(2)},
+		Entries: []interface{}{
+			"When you cast a spell that has a casting time of 1 action, you can change the casting time to 1 bonus action for this casting.",
+		},
+	}
+
+	md, err := optionalFeatureToMarkdown(feature)
+	if err != nil {
+		t.Fatalf("optionalFeatureToMarkdown() error = %v", err)
+	}
+
+	expected := "# Quickened Spell\n\n*Metamagic*\n\n**Cost:** 2 Sorcery Point\n\nWhen you cast a spell that has a casting time of 1 action, you can change the casting time to 1 bonus action for this casting.\n\n**Source:** PHB, page 102\n"
+	if md != expected {
+		t.Errorf("optionalFeatureToMarkdown() = %q, want %q", md, expected)
+	}
+}
+
+func TestParseFeatsAndOptionalFeatures_WithMockData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "feats-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	featFile := FeatFile{
+		Feat: []Feat{
+			{Name: "Alert", Source: "PHB", Page: 165, Entries: []interface{}{"Always on the lookout for danger."}},
+		},
+	}
+	featData, err := json.Marshal(featFile)
+	if err != nil {
+		t.Fatalf("Failed to marshal feat data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "feats.json"), featData, 0644); err != nil {
+		t.Fatalf("Failed to write feats.json: %v", err)
+	}
+
+	optionalFeatureFile := OptionalFeatureFile{
+		OptionalFeature: []OptionalFeature{
+			{Name: "Agonizing Blast", Source: "PHB", FeatureType: []string{"EI"}},
+			{Name: "Archery", Source: "TCE", FeatureType: []string{"FS:F", "FS:R"}},
+		},
+	}
+	optionalFeatureData, err := json.Marshal(optionalFeatureFile)
+	if err != nil {
+		t.Fatalf("Failed to marshal optional feature data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "optionalfeatures.json"), optionalFeatureData, 0644); err != nil {
+		t.Fatalf("Failed to write optionalfeatures.json: %v", err)
+	}
+
+	ctx := context.Background()
+	if err := parseFeats(ctx, dataDir, outDir); err != nil {
+		t.Fatalf("parseFeats() error = %v", err)
+	}
+	if err := parseOptionalFeatures(ctx, dataDir, outDir); err != nil {
+		t.Fatalf("parseOptionalFeatures() error = %v", err)
+	}
+
+	expectedFiles := []string{
+		filepath.Join("feats", "Alert.md"),
+		filepath.Join("optional-features", "Eldritch Invocation", "Agonizing Blast.md"),
+		filepath.Join("optional-features", "Fighting Style", "Archery.md"),
+	}
+	for _, expectedFile := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(outDir, expectedFile)); os.IsNotExist(err) {
+			t.Errorf("Expected file %s does not exist", expectedFile)
+		}
+	}
+
+	archeryContent, err := os.ReadFile(filepath.Join(outDir, "optional-features", "Fighting Style", "Archery.md"))
+	if err != nil {
+		t.Fatalf("Failed to read Archery.md: %v", err)
+	}
+	if !strings.Contains(string(archeryContent), "*Fighting Style (Fighter), Fighting Style (Ranger)*") {
+		t.Errorf("Archery.md missing feature types, got %s", archeryContent)
+	}
+}
@@ -0,0 +1,299 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ItemProperty represents a weapon property definition such as Versatile or Thrown
+type ItemProperty struct {
+	Abbreviation string        `json:"abbreviation"`
+	Source       string        `json:"source"`
+	Page         int           `json:"page,omitempty"`
+	Name         string        `json:"name,omitempty"` // Older data only names the property in its first entry
+	Entries      []interface{} `json:"entries,omitempty"`
+}
+
+// ItemTypeDefinition represents an item type definition such as Melee Weapon or Heavy Armor
+type ItemTypeDefinition struct {
+	Abbreviation string        `json:"abbreviation"`
+	Source       string        `json:"source"`
+	Page         int           `json:"page,omitempty"`
+	Name         string        `json:"name,omitempty"` // Older data only names the type in its first entry
+	Entries      []interface{} `json:"entries,omitempty"`
+}
+
+// ItemMastery represents a weapon mastery property such as Sap or Cleave
+type ItemMastery struct {
+	Name    string        `json:"name"`
+	Source  string        `json:"source"`
+	Page    int           `json:"page,omitempty"`
+	Entries []interface{} `json:"entries,omitempty"`
+}
+
+// ItemDefinitions holds the property, type and mastery definitions from items-base.json,
+// indexed by abbreviation (or name for masteries) with and without a "|source" suffix
+type ItemDefinitions struct {
+	Properties map[string]ItemProperty
+	Types      map[string]ItemTypeDefinition
+	Masteries  map[string]ItemMastery
+}
+
+// defaultPropertyNames are used when an item property has no definition in the data
+var defaultPropertyNames = map[string]string{
+	"A":   "Ammunition",
+	"AF":  "Ammunition (Futuristic)",
+	"BF":  "Burst Fire",
+	"F":   "Finesse",
+	"H":   "Heavy",
+	"L":   "Light",
+	"LD":  "Loading",
+	"R":   "Reach",
+	"RLD": "Reload",
+	"S":   "Special",
+	"T":   "Thrown",
+	"2H":  "Two-Handed",
+	"V":   "Versatile",
+}
+
+// defaultTypeNames are used when an item type has no definition in the data
+var defaultTypeNames = map[string]string{
+	"$":   "Treasure",
+	"$A":  "Treasure (Art Object)",
+	"$C":  "Treasure (Coinage)",
+	"$G":  "Treasure (Gemstone)",
+	"A":   "Ammunition",
+	"AF":  "Ammunition",
+	"AIR": "Vehicle (Air)",
+	"AT":  "Artisan's Tools",
+	"EXP": "Explosive",
+	"FD":  "Food and Drink",
+	"G":   "Adventuring Gear",
+	"GS":  "Gaming Set",
+	"GV":  "Generic Variant",
+	"HA":  "Heavy Armor",
+	"INS": "Instrument",
+	"LA":  "Light Armor",
+	"M":   "Melee Weapon",
+	"MA":  "Medium Armor",
+	"MNT": "Mount",
+	"OTH": "Other",
+	"P":   "Potion",
+	"R":   "Ranged Weapon",
+	"RD":  "Rod",
+	"RG":  "Ring",
+	"S":   "Shield",
+	"SC":  "Scroll",
+	"SCF": "Spellcasting Focus",
+	"SHP": "Vehicle (Water)",
+	"SPC": "Vehicle (Space)",
+	"ST":  "Staff",
+	"T":   "Tools",
+	"TAH": "Tack and Harness",
+	"TB":  "Trade Bar",
+	"TG":  "Trade Good",
+	"VEH": "Vehicle (Land)",
+	"WD":  "Wand",
+}
+
+// newItemDefinitions indexes the item definitions. Keys without a source resolve to the first definition
+// listed, which is the original printing.
+func newItemDefinitions(itemFile ItemFile) *ItemDefinitions {
+	definitions := &ItemDefinitions{
+		Properties: make(map[string]ItemProperty),
+		Types:      make(map[string]ItemTypeDefinition),
+		Masteries:  make(map[string]ItemMastery),
+	}
+	for _, property := range itemFile.ItemProperty {
+		property.Name = getDefinitionName(property.Name, property.Entries)
+		addDefinition(definitions.Properties, property.Abbreviation, property.Source, property)
+	}
+	for _, itemType := range itemFile.ItemType {
+		itemType.Name = getDefinitionName(itemType.Name, itemType.Entries)
+		addDefinition(definitions.Types, itemType.Abbreviation, itemType.Source, itemType)
+	}
+	for _, mastery := range itemFile.ItemMastery {
+		addDefinition(definitions.Masteries, mastery.Name, mastery.Source, mastery)
+	}
+	return definitions
+}
+
+// addDefinition indexes a definition by "key|source" and, if not already taken, by key alone
+func addDefinition[T any](index map[string]T, key, source string, definition T) {
+	key = strings.ToUpper(key)
+	index[key+"|"+strings.ToUpper(source)] = definition
+	if _, ok := index[key]; !ok {
+		index[key] = definition
+	}
+}
+
+// findDefinition looks up a reference such as "V" or "V|XPHB" in a definition index
+func findDefinition[T any](index map[string]T, reference string) (T, bool) {
+	key, source := parseReference(reference)
+	key = strings.ToUpper(key)
+	if source != "" {
+		if definition, ok := index[key+"|"+source]; ok {
+			return definition, true
+		}
+	}
+	definition, ok := index[key]
+	return definition, ok
+}
+
+// getDefinitionName returns the name of a definition, falling back to the name of its first entry
+func getDefinitionName(name string, entries []interface{}) string {
+	if name != "" {
+		return name
+	}
+	if len(entries) > 0 {
+		if entry, ok := entries[0].(map[string]interface{}); ok {
+			if entryName, ok := entry["name"].(string); ok {
+				return entryName
+			}
+		}
+	}
+	return ""
+}
+
+// propertyName returns the full name of an item property such as "V|XPHB" -> "Versatile"
+func (d *ItemDefinitions) propertyName(reference string) string {
+	if d != nil {
+		if property, ok := findDefinition(d.Properties, reference); ok && property.Name != "" {
+			return property.Name
+		}
+	}
+	abbreviation, _ := parseReference(reference)
+	if name, ok := defaultPropertyNames[strings.ToUpper(abbreviation)]; ok {
+		return name
+	}
+	return abbreviation
+}
+
+// typeName returns the name of an item type such as "SCF|XPHB" -> "Spellcasting Focus".
+// Unknown types, such as the free text used by older data, are returned as they are.
+func (d *ItemDefinitions) typeName(reference string) string {
+	if d != nil {
+		if itemType, ok := findDefinition(d.Types, reference); ok && itemType.Name != "" {
+			return itemType.Name
+		}
+	}
+	abbreviation, _ := parseReference(reference)
+	if name, ok := defaultTypeNames[strings.ToUpper(abbreviation)]; ok {
+		return name
+	}
+	return abbreviation
+}
+
+// masteryName returns the name of a weapon mastery such as "sap|XPHB" -> "Sap"
+func (d *ItemDefinitions) masteryName(reference string) string {
+	if d != nil {
+		if mastery, ok := findDefinition(d.Masteries, reference); ok {
+			return mastery.Name
+		}
+	}
+	name, _ := parseReference(reference)
+	return titleCase(name)
+}
+
+// The kinds of item definition, which are also part of their note names
+const (
+	itemPropertyKind  = "Item Property"
+	itemTypeKind      = "Item Type"
+	weaponMasteryKind = "Weapon Mastery"
+)
+
+// writeItemDefinitionNotes writes a note for each item property, type and weapon mastery in items-base.json
+// so items can link to them
+func writeItemDefinitionNotes(outDirectory string, itemFile ItemFile) error {
+	var properties, types, masteries []definitionNote
+	for _, property := range itemFile.ItemProperty {
+		properties = append(properties, definitionNote{getDefinitionName(property.Name, property.Entries), property.Source, property.Page, property.Entries})
+	}
+	for _, itemType := range itemFile.ItemType {
+		types = append(types, definitionNote{getDefinitionName(itemType.Name, itemType.Entries), itemType.Source, itemType.Page, itemType.Entries})
+	}
+	for _, mastery := range itemFile.ItemMastery {
+		masteries = append(masteries, definitionNote{mastery.Name, mastery.Source, mastery.Page, mastery.Entries})
+	}
+
+	groups := []struct {
+		kind   string
+		folder string
+		notes  []definitionNote
+	}{
+		{itemPropertyKind, "item-properties", properties},
+		{itemTypeKind, "item-types", types},
+		{weaponMasteryKind, "weapon-masteries", masteries},
+	}
+
+	for _, group := range groups {
+		if len(group.notes) == 0 {
+			continue
+		}
+
+		// Create output directory if it doesn't exist
+		outDir := filepath.Join(outDirectory, group.folder)
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		for _, note := range group.notes {
+			if note.name == "" {
+				continue
+			}
+			mdContent, err := definitionToMarkdown(note, group.kind)
+			if err != nil {
+				return fmt.Errorf("failed to convert %s to markdown: %w", strings.ToLower(group.kind), err)
+			}
+
+			if err := writeNote(outDir, definitionNoteName(note.name, group.kind), mdContent); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// definitionNoteName returns the note name for an item definition, e.g. "Light (Item Property)". The kind is
+// included because names such as Light, Shield and Slow are also the names of spells and items.
+func definitionNoteName(name, kind string) string {
+	return fmt.Sprintf("%s (%s)", name, kind)
+}
+
+// definitionNote is the common shape of the item property, type and mastery notes
+type definitionNote struct {
+	name    string
+	source  string
+	page    int
+	entries []interface{}
+}
+
+// definitionToMarkdown converts an item property, type or mastery definition to Markdown format
+func definitionToMarkdown(note definitionNote, kind string) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", note.name))
+	md.WriteString(fmt.Sprintf("*%s*\n\n", kind))
+
+	// Older data repeats the name as a heading in its only entry, so that entry is unwrapped
+	entries := note.entries
+	if len(entries) == 1 {
+		if entry, ok := entries[0].(map[string]interface{}); ok && entry["name"] == note.name {
+			entries, _ = entry["entries"].([]interface{})
+		}
+	}
+	entriesToMarkdown(&md, entries, 2)
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", note.source))
+	if note.page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", note.page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
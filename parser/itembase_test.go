@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testItemFile() ItemFile {
+	return ItemFile{
+		ItemProperty: []ItemProperty{
+			{
+				Abbreviation: "T",
+				Source:       "PHB",
+				Entries: []interface{}{
+					map[string]interface{}{"type": "entries", "name": "Thrown", "entries": []interface{}{"You can throw the weapon."}},
+				},
+			},
+			{Abbreviation: "T", Source: "XPHB", Name: "Thrown", Entries: []interface{}{"You can throw the weapon to make a ranged attack."}},
+		},
+		ItemType: []ItemTypeDefinition{
+			{Abbreviation: "M", Source: "PHB", Name: "Melee Weapon"},
+		},
+		ItemMastery: []ItemMastery{
+			{Name: "Vex", Source: "XPHB", Page: 214, Entries: []interface{}{"You have Advantage on your next attack roll."}},
+		},
+	}
+}
+
+func TestItemDefinitions(t *testing.T) {
+	definitions := newItemDefinitions(testItemFile())
+
+	tests := []struct {
+		name      string
+		lookup    func(string) string
+		reference string
+		expected  string
+	}{
+		{"Property from entry name", definitions.propertyName, "T", "Thrown"},
+		{"Property with source", definitions.propertyName, "T|XPHB", "Thrown"},
+		{"Property without definition", definitions.propertyName, "2H|XPHB", "Two-Handed"},
+		{"Unknown property", definitions.propertyName, "ZZ", "ZZ"},
+		{"Mastery", definitions.masteryName, "vex|XPHB", "Vex"},
+		{"Mastery without definition", definitions.masteryName, "topple|XPHB", "Topple"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := tt.lookup(tt.reference); result != tt.expected {
+				t.Errorf("lookup(%q) = %q, want %q", tt.reference, result, tt.expected)
+			}
+		})
+	}
+
+	// Lookups also work without any definitions
+	var empty *ItemDefinitions
+	if result := empty.propertyName("V|XPHB"); result != "Versatile" {
+		t.Errorf("propertyName() without definitions = %q, want %q", result, "Versatile")
+	}
+}
+
+func TestItemToMarkdown_ThrownWeapon(t *testing.T) {
+	item := Item{
+		Name:     "Dagger",
+		Type:     "M",
+		Dmg1:     "1d4",
+		DmgType:  "P",
+		Range:    "20/60",
+		Property: []interface{}{"F", map[string]interface{}{"uid": "T|XPHB", "note": "see below"}},
+		Source:   "PHB",
+	}
+
+	result, err := itemToMarkdown(item, newItemDefinitions(testItemFile()))
+	if err != nil {
+		t.Fatalf("itemToMarkdown() error = %v", err)
+	}
+
+	expected := "**Properties:** [[Finesse (Item Property)|finesse]], [[Thrown (Item Property)|thrown]] (range 20/60 ft.) (see below)\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("itemToMarkdown() missing %q, got %s", expected, result)
+	}
+	if strings.Contains(result, "**Range:**") {
+		t.Errorf("itemToMarkdown() repeated the range of a thrown weapon, got %s", result)
+	}
+}
+
+func TestDefinitionToMarkdown(t *testing.T) {
+	note := definitionNote{
+		name:    "Thrown",
+		source:  "PHB",
+		page:    147,
+		entries: testItemFile().ItemProperty[0].Entries,
+	}
+
+	result, err := definitionToMarkdown(note, "Item Property")
+	if err != nil {
+		t.Fatalf("definitionToMarkdown() error = %v", err)
+	}
+
+	expected := "# Thrown\n\n*Item Property*\n\nYou can throw the weapon.\n\n**Source:** PHB, page 147\n"
+	if result != expected {
+		t.Errorf("definitionToMarkdown() = %q, want %q", result, expected)
+	}
+}
+
+func TestWriteItemDefinitionNotes(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "itembase-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := writeItemDefinitionNotes(tempDir, testItemFile()); err != nil {
+		t.Fatalf("writeItemDefinitionNotes() error = %v", err)
+	}
+
+	expectedFiles := []string{
+		filepath.Join("item-properties", "Thrown (Item Property).md"),
+		filepath.Join("item-types", "Melee Weapon (Item Type).md"),
+		filepath.Join("weapon-masteries", "Vex (Weapon Mastery).md"),
+	}
+	for _, expectedFile := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(tempDir, expectedFile)); os.IsNotExist(err) {
+			t.Errorf("Expected file %s does not exist", expectedFile)
+		}
+	}
+}
@@ -5,112 +5,319 @@ import (
 	"encoding/json"
 	"fmt"
 	"os"
+	"path"
 	"path/filepath"
+	"sort"
 	"strings"
+	"unicode"
 )
 
 type ItemFile struct {
-	Item []Item `json:"item"`
+	Item         []Item               `json:"item"`
+	BaseItem     []Item               `json:"baseitem"`
+	ItemGroup    []Item               `json:"itemGroup"`
+	ItemProperty []ItemProperty       `json:"itemProperty"`
+	ItemType     []ItemTypeDefinition `json:"itemType"`
+	ItemMastery  []ItemMastery        `json:"itemMastery"`
 }
 
 type Item struct {
-	Name               string        `json:"name"`
-	Type               string        `json:"type"`
-	Rarity             string        `json:"rarity,omitempty"`
-	Weight             float64       `json:"weight,omitempty"`
-	Value              interface{}   `json:"value,omitempty"`
-	Source             string        `json:"source"`
-	Page               int           `json:"page,omitempty"`
-	Entries            []interface{} `json:"entries,omitempty"`
-	Attunement         interface{}   `json:"attunement,omitempty"`
-	Tier               string        `json:"tier,omitempty"`
-	RequiresAttunement interface{}   `json:"reqAttune,omitempty"`
+	Name               string         `json:"name"`
+	Type               string         `json:"type"`
+	Rarity             string         `json:"rarity,omitempty"`
+	Weight             float64        `json:"weight,omitempty"`
+	Value              interface{}    `json:"value,omitempty"`
+	Source             string         `json:"source"`
+	Page               int            `json:"page,omitempty"`
+	Entries            []interface{}  `json:"entries,omitempty"`
+	Attunement         interface{}    `json:"attunement,omitempty"`
+	Tier               string         `json:"tier,omitempty"`
+	RequiresAttunement interface{}    `json:"reqAttune,omitempty"`
+	BaseItem           string         `json:"baseItem,omitempty"`       // e.g. "longsword|phb"
+	GenericVariant     *ItemReference `json:"genericVariant,omitempty"` // The magic variant a specific item was made from
+	ReprintedAs        []interface{}  `json:"reprintedAs,omitempty"`    // Can be "Bag of Holding|XDMG" or {"uid": "...", "tag": "item"}
+
+	// Weapon and armor stats
+	WeaponCategory string        `json:"weaponCategory,omitempty"`
+	Dmg1           string        `json:"dmg1,omitempty"`
+	Dmg2           string        `json:"dmg2,omitempty"` // Versatile damage
+	DmgType        string        `json:"dmgType,omitempty"`
+	Property       []interface{} `json:"property,omitempty"` // Can be "V|XPHB" or {"uid": "V|XPHB", "note": "..."}
+	Range          string        `json:"range,omitempty"`
+	Mastery        []interface{} `json:"mastery,omitempty"` // Can be "Sap|XPHB" or {"uid": "Sap|XPHB", "note": "..."}
+	AC             int           `json:"ac,omitempty"`
+	Strength       interface{}   `json:"strength,omitempty"` // Can be a string such as "13" or a number
+	Stealth        bool          `json:"stealth,omitempty"`
+	Wondrous       bool          `json:"wondrous,omitempty"`
+
+	// Magic item properties
+	Charges          interface{} `json:"charges,omitempty"`        // Usually a number, sometimes a dice expression
+	Recharge         string      `json:"recharge,omitempty"`       // e.g. "dawn", "restLong"
+	RechargeAmount   interface{} `json:"rechargeAmount,omitempty"` // Can be a number or a dice expression
+	BonusWeapon      string      `json:"bonusWeapon,omitempty"`
+	BonusAC          string      `json:"bonusAc,omitempty"`
+	BonusSpellAttack string      `json:"bonusSpellAttack,omitempty"`
+	BonusSavingThrow string      `json:"bonusSavingThrow,omitempty"`
+	AttachedSpells   interface{} `json:"attachedSpells,omitempty"` // A list of spells, or lists keyed by usage such as "daily"
+	Curse            bool        `json:"curse,omitempty"`
+	Sentient         bool        `json:"sentient,omitempty"`
+	Focus            interface{} `json:"focus,omitempty"` // true, or the classes that can use the item as a focus
+	LootTables       []string    `json:"lootTables,omitempty"`
+
+	// Groups and packs
+	Items        []string      `json:"items,omitempty"`        // Members of an item group, e.g. "Crystal|PHB"
+	PackContents []interface{} `json:"packContents,omitempty"` // Can be "backpack|PHB" or {"item": "torch|PHB", "quantity": 10}
+	MemberOf     []string      `json:"-"`                      // The groups and packs the item belongs to
+
+	ValueEstimate string `json:"-"` // A price range by rarity, shown when the item has no value of its own
+	NoteName      string `json:"-"` // The note a specific magic item is written to, when it differs from its name
+
+	// Lore and images, stored separately in fluff-items.json
+	HasFluff       bool          `json:"hasFluff,omitempty"`
+	HasFluffImages bool          `json:"hasFluffImages,omitempty"`
+	Lore           []interface{} `json:"-"`
+	Images         []string      `json:"-"` // Image paths within the vault, or URLs for external images
 	// Additional fields can be added as needed
 }
 
+// ItemReference identifies an item by name and source
+type ItemReference struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
+// itemContext holds the lookups and settings shared by every item note
+type itemContext struct {
+	definitions    *ItemDefinitions
+	memberships    itemMemberships
+	fluff          fluffLookup
+	sources        *sourceFilter
+	reprints       *reprintFilter
+	imageDirectory string
+	estimateValues bool
+}
+
 // parseItems parses the item data from the specified directory and writes it to the output directory.
-func parseItems(ctx context.Context, dataDirectory, outDirectory string) error {
+// Images from the item fluff are resolved against imageDirectory, and are left out when it is empty.
+// The edition decides which printings of reprinted items are written, and the filter which sources are.
+// If estimateValues is set, magic items without a value are given the price range for their rarity.
+func parseItems(ctx context.Context, dataDirectory, outDirectory, imageDirectory, edition string, filter *sourceFilter, estimateValues bool) error {
 	// Create output directory if it doesn't exist
 	outDir := filepath.Join(outDirectory, "items")
 	if err := os.MkdirAll(outDir, 0755); err != nil {
 		return fmt.Errorf("failed to create output directory: %w", err)
 	}
 
+	// The items-base.json file also defines the item properties, types and weapon masteries that items refer to
+	baseItemFile, err := readItemFile(dataDirectory, "items-base.json")
+	if err != nil {
+		return fmt.Errorf("failed to process items-base.json: %w", err)
+	}
+	definitions := newItemDefinitions(baseItemFile)
+	if err := writeItemDefinitionNotes(outDirectory, baseItemFile); err != nil {
+		return fmt.Errorf("failed to process items-base.json: %w", err)
+	}
+
+	itemFile, err := readItemFile(dataDirectory, "items.json")
+	if err != nil {
+		return fmt.Errorf("failed to process items.json: %w", err)
+	}
+
+	fluff, err := readItemFluff(dataDirectory)
+	if err != nil {
+		return err
+	}
+
+	itemCtx := itemContext{
+		definitions: definitions,
+		// Items that belong to a group or pack in either file link back to it
+		memberships:    newItemMemberships(itemFile, baseItemFile),
+		fluff:          fluff,
+		sources:        filter,
+		reprints:       newItemReprintFilter(edition, filter, itemFile, baseItemFile),
+		imageDirectory: imageDirectory,
+		estimateValues: estimateValues,
+	}
+
 	// Process the main items.json file
-	if err := processItemFile(ctx, dataDirectory, outDir, "items.json"); err != nil {
+	if err := processItemFile(ctx, outDir, itemFile, itemCtx); err != nil {
 		return fmt.Errorf("failed to process items.json: %w", err)
 	}
 
 	// Process the items-base.json file
-	if err := processItemFile(ctx, dataDirectory, outDir, "items-base.json"); err != nil {
+	if err := processItemFile(ctx, outDir, baseItemFile, itemCtx); err != nil {
 		return fmt.Errorf("failed to process items-base.json: %w", err)
 	}
 
 	return nil
 }
 
-// processItemFile processes a single item file and generates Markdown for each item
-func processItemFile(ctx context.Context, dataDirectory, outDir, filename string) error {
-	// Read and parse the item file
+// readItemFile reads and parses an item file such as items.json or items-base.json
+func readItemFile(dataDirectory, filename string) (ItemFile, error) {
+	var itemFile ItemFile
+
 	filePath := filepath.Join(dataDirectory, filename)
 	fileData, err := os.ReadFile(filePath)
 	if err != nil {
-		return fmt.Errorf("failed to read item file: %w", err)
+		return itemFile, fmt.Errorf("failed to read item file: %w", err)
 	}
 
-	var itemFile ItemFile
 	if err := json.Unmarshal(fileData, &itemFile); err != nil {
-		return fmt.Errorf("failed to parse item file: %w", err)
+		return itemFile, fmt.Errorf("failed to parse item file: %w", err)
 	}
 
-	// Process each item
-	for _, item := range itemFile.Item {
-		mdContent, err := itemToMarkdown(item)
-		if err != nil {
-			return fmt.Errorf("failed to convert item to markdown: %w", err)
+	return itemFile, nil
+}
+
+// newItemReprintFilter creates a reprint filter that knows the reprints of every item in the item files
+func newItemReprintFilter(edition string, filter *sourceFilter, itemFiles ...ItemFile) *reprintFilter {
+	reprints := newReprintFilter(edition, filter)
+	for _, itemFile := range itemFiles {
+		for _, item := range append(append(itemFile.Item, itemFile.BaseItem...), itemFile.ItemGroup...) {
+			reprints.add(item.Source, item.ReprintedAs)
 		}
+	}
+	return reprints
+}
+
+// readItemFluff reads the lore and images in fluff-items.json. The file is optional, so a missing file is not an
+// error.
+func readItemFluff(dataDirectory string) (fluffLookup, error) {
+	fluffData, err := os.ReadFile(filepath.Join(dataDirectory, "fluff-items.json"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read item fluff file: %w", err)
+	}
+
+	var fluffFile struct {
+		ItemFluff []Fluff `json:"itemFluff"`
+	}
+	if err := json.Unmarshal(fluffData, &fluffFile); err != nil {
+		return nil, fmt.Errorf("failed to parse item fluff file: %w", err)
+	}
 
-		// Create a filename for the item
-		safeItemName := strings.ReplaceAll(item.Name, "/", "-")
-		safeItemName = strings.ReplaceAll(safeItemName, "\\", "-")
-		safeItemName = strings.ReplaceAll(safeItemName, ":", "-")
-		safeItemName = strings.ReplaceAll(safeItemName, "*", "-")
-		safeItemName = strings.ReplaceAll(safeItemName, "?", "-")
-		safeItemName = strings.ReplaceAll(safeItemName, "\"", "-")
-		safeItemName = strings.ReplaceAll(safeItemName, "<", "-")
-		safeItemName = strings.ReplaceAll(safeItemName, ">", "-")
-		safeItemName = strings.ReplaceAll(safeItemName, "|", "-")
+	return newFluffLookup(fluffFile.ItemFluff), nil
+}
 
-		mdFilePath := filepath.Join(outDir, safeItemName+".md")
+// applyItemFluff sets the lore and images of an item from its fluff, following the item's hasFluff and
+// hasFluffImages flags
+func applyItemFluff(item *Item, fluff *Fluff, imageDirectory string) {
+	if fluff == nil {
+		return
+	}
+	if item.HasFluff {
+		item.Lore = fluff.Entries
+	}
+	if item.HasFluffImages {
+		item.Images = fluffImagePaths(fluff.Images, imageDirectory)
+	}
+}
 
-		// Write the markdown file
-		if err := os.WriteFile(mdFilePath, []byte(mdContent), 0644); err != nil {
-			return fmt.Errorf("failed to write markdown file: %w", err)
+// fluffImagePaths returns the location of each fluff image. Internal images such as "items/DMG/Bag of Holding.webp"
+// are resolved against the image directory and skipped when there is none; external images keep their URL.
+func fluffImagePaths(images []interface{}, imageDirectory string) []string {
+	var paths []string
+	for _, image := range images {
+		imageMap, ok := image.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		href, ok := imageMap["href"].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		switch href["type"] {
+		case "internal":
+			if p, ok := href["path"].(string); ok && imageDirectory != "" {
+				paths = append(paths, path.Join(filepath.ToSlash(imageDirectory), p))
+			}
+		case "external":
+			if url, ok := href["url"].(string); ok {
+				paths = append(paths, url)
+			}
+		}
+	}
+	return paths
+}
+
+// processItemFile processes a single item file and generates Markdown for each item
+func processItemFile(ctx context.Context, outDir string, itemFile ItemFile, itemCtx itemContext) error {
+	// Process each item. items-base.json keeps its items under "baseitem", and items.json keeps item groups
+	// such as Arcane Focus under "itemGroup"
+	items := append(append(itemFile.Item, itemFile.BaseItem...), itemFile.ItemGroup...)
+	for _, item := range items {
+		if !itemCtx.sources.keep(item.Source) || !itemCtx.reprints.keep(item.Name, item.Source, item.ReprintedAs) {
+			continue
+		}
+		item.ReprintedAs = itemCtx.reprints.links(item.ReprintedAs)
+
+		item.MemberOf = itemCtx.memberships[strings.ToLower(item.Name)]
+		if itemCtx.estimateValues {
+			item.ValueEstimate = estimateItemValue(item.Rarity)
+		}
+		if item.HasFluff || item.HasFluffImages {
+			applyItemFluff(&item, itemCtx.fluff.find(item.Name, item.Source), itemCtx.imageDirectory)
+		}
+
+		mdContent, err := itemToMarkdown(item, itemCtx.definitions)
+		if err != nil {
+			return fmt.Errorf("failed to convert item to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, itemCtx.reprints.noteName(item.Name, item.Source, item.ReprintedAs), mdContent); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
-// itemToMarkdown converts an item to Markdown format
-func itemToMarkdown(item Item) (string, error) {
+// itemToMarkdown converts an item to Markdown format. The definitions are used to name and link item
+// properties and may be nil.
+func itemToMarkdown(item Item, definitions *ItemDefinitions) (string, error) {
 	var md strings.Builder
 
+	writeItemDetails(&md, item, definitions)
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", item.Source))
+	if item.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", item.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// writeItemDetails writes everything about an item up to its source: the title, type, properties and description
+func writeItemDetails(md *strings.Builder, item Item, definitions *ItemDefinitions) {
+	// Frontmatter, so charges can be tracked with Obsidian properties
+	if item.Charges != nil {
+		md.WriteString("---\n")
+		md.WriteString(fmt.Sprintf("charges: %v\n", formatCharges(item.Charges)))
+		if item.Recharge != "" {
+			md.WriteString(fmt.Sprintf("recharge: %s\n", item.Recharge))
+		}
+		md.WriteString("---\n\n")
+	}
+
 	// Title
 	md.WriteString(fmt.Sprintf("# %s\n\n", item.Name))
+	writeReprintBanner(md, "item", item.ReprintedAs)
 
-	// Basic info
-	var typeRarity string
-	if item.Rarity != "" {
-		if item.Type != "" {
-			typeRarity = fmt.Sprintf("*%s, %s*", item.Type, item.Rarity)
-		} else {
-			typeRarity = fmt.Sprintf("*%s*", item.Rarity)
-		}
-	} else {
-		typeRarity = fmt.Sprintf("*%s*", item.Type)
+	// Basic info, e.g. "Wondrous item, rare" or "Martial melee weapon"
+	var typeRarity []string
+	if itemType := formatItemType(item, definitions); itemType != "" {
+		typeRarity = append(typeRarity, itemType)
+	}
+	if rarity := formatRarity(item.Rarity); rarity != "" {
+		typeRarity = append(typeRarity, rarity)
+	}
+	if len(typeRarity) > 0 {
+		md.WriteString(fmt.Sprintf("*%s*\n\n", sentenceCase(strings.Join(typeRarity, ", "))))
 	}
-	md.WriteString(typeRarity + "\n\n")
 
 	// Attunement
 	if item.RequiresAttunement != nil {
@@ -137,6 +344,15 @@ func itemToMarkdown(item Item) (string, error) {
 		}
 	}
 
+	// Images, embedded from the vault or linked from the web
+	for _, image := range item.Images {
+		if strings.HasPrefix(image, "http://") || strings.HasPrefix(image, "https://") {
+			md.WriteString(fmt.Sprintf("![%s](%s)\n\n", item.Name, image))
+		} else {
+			md.WriteString(fmt.Sprintf("![[%s]]\n\n", image))
+		}
+	}
+
 	// Weight and value
 	if item.Weight > 0 {
 		md.WriteString(fmt.Sprintf("**Weight:** %.1f lb.\n\n", item.Weight))
@@ -145,49 +361,481 @@ func itemToMarkdown(item Item) (string, error) {
 	if item.Value != nil {
 		switch v := item.Value.(type) {
 		case float64:
-			md.WriteString(fmt.Sprintf("**Value:** %.0f gp\n\n", v))
+			// Values are stored in copper pieces
+			md.WriteString(fmt.Sprintf("**Value:** %s\n\n", formatCoins(v)))
 		case map[string]interface{}:
 			if quantity, ok := v["quantity"].(float64); ok {
 				if unit, ok := v["unit"].(string); ok {
-					md.WriteString(fmt.Sprintf("**Value:** %.0f %s\n\n", quantity, unit))
+					md.WriteString(fmt.Sprintf("**Value:** %s %s\n\n", formatNumber(quantity), unit))
 				}
 			}
 		}
+	} else if item.ValueEstimate != "" {
+		md.WriteString(fmt.Sprintf("**Value:** %s (estimated)\n\n", item.ValueEstimate))
+	}
+
+	writeItemStats(md, item, definitions)
+	writeItemProperties(md, item)
+
+	// Variants link back to the generic variant and base item they were made from
+	if item.GenericVariant != nil {
+		md.WriteString(fmt.Sprintf("**Variant of:** %s\n\n", linkTo(item.GenericVariant.Name)))
+	}
+	if item.BaseItem != "" {
+		md.WriteString(fmt.Sprintf("**Base Item:** %s\n\n", linkToReference(item.BaseItem)))
+	}
+	if len(item.MemberOf) > 0 {
+		links := make([]string, 0, len(item.MemberOf))
+		for _, group := range item.MemberOf {
+			links = append(links, linkTo(group))
+		}
+		md.WriteString(fmt.Sprintf("**Part of:** %s\n\n", strings.Join(links, ", ")))
 	}
 
 	// Description
-	if item.Entries != nil && len(item.Entries) > 0 {
-		for _, entry := range item.Entries {
-			switch e := entry.(type) {
-			case string:
-				md.WriteString(processSpecialFormatting(e) + "\n\n")
-			case map[string]interface{}:
-				if entryType, ok := e["type"].(string); ok {
-					if entryType == "list" && e["items"] != nil {
-						if items, ok := e["items"].([]interface{}); ok {
-							for _, item := range items {
-								if itemStr, ok := item.(string); ok {
-									md.WriteString("- " + processSpecialFormatting(itemStr) + "\n")
-								}
-							}
-							md.WriteString("\n")
-						}
-					} else if entryType == "table" {
-						// Handle tables if needed
+	entriesToMarkdown(md, item.Entries, 2)
+
+	// Lore
+	if len(item.Lore) > 0 {
+		md.WriteString("## Lore\n\n")
+		entriesToMarkdown(md, item.Lore, 3)
+	}
+
+	// Group members and pack contents
+	if len(item.Items) > 0 {
+		md.WriteString("## Items\n\n")
+		for _, member := range item.Items {
+			md.WriteString(fmt.Sprintf("- %s\n", linkToReference(member)))
+		}
+		md.WriteString("\n")
+	}
+	if len(item.PackContents) > 0 {
+		md.WriteString("## Contents\n\n")
+		for _, content := range item.PackContents {
+			if text := formatPackContent(content); text != "" {
+				md.WriteString(fmt.Sprintf("- %s\n", text))
+			}
+		}
+		md.WriteString("\n")
+	}
+}
+
+// itemMemberships maps the lower case name of an item to the groups and packs it belongs to
+type itemMemberships map[string][]string
+
+// newItemMemberships indexes the members of every item group and the contents of every pack in the item files
+func newItemMemberships(itemFiles ...ItemFile) itemMemberships {
+	memberships := make(itemMemberships)
+	add := func(reference, group string) {
+		name, _ := parseReference(reference)
+		key := strings.ToLower(name)
+		for _, existing := range memberships[key] {
+			if existing == group {
+				return
+			}
+		}
+		memberships[key] = append(memberships[key], group)
+	}
+
+	for _, itemFile := range itemFiles {
+		for _, item := range append(append(itemFile.Item, itemFile.BaseItem...), itemFile.ItemGroup...) {
+			for _, member := range item.Items {
+				add(member, item.Name)
+			}
+			for _, content := range item.PackContents {
+				switch c := content.(type) {
+				case string:
+					add(c, item.Name)
+				case map[string]interface{}:
+					if reference, ok := c["item"].(string); ok {
+						add(reference, item.Name)
 					}
 				}
 			}
 		}
 	}
+	return memberships
+}
 
-	// Source
-	md.WriteString(fmt.Sprintf("**Source:** %s", item.Source))
-	if item.Page > 0 {
-		md.WriteString(fmt.Sprintf(", page %d", item.Page))
+// formatPackContent formats an item in a pack, e.g. {"item": "torch|PHB", "quantity": 10} -> "[[Torch]] ×10".
+// Contents that aren't items, such as "a small knife", are given as {"special": "..."}.
+func formatPackContent(content interface{}) string {
+	switch c := content.(type) {
+	case string:
+		return linkToReference(c)
+	case map[string]interface{}:
+		var text string
+		if reference, ok := c["item"].(string); ok {
+			text = linkToReference(reference)
+			if display, ok := c["displayName"].(string); ok && display != "" {
+				name, _ := parseReference(reference)
+				text = fmt.Sprintf("[[%s|%s]]", safeFileName(titleCase(name)), display)
+			}
+		} else if special, ok := c["special"].(string); ok {
+			text = processSpecialFormatting(special)
+		}
+		if quantity, ok := c["quantity"].(float64); ok && quantity > 1 {
+			text += fmt.Sprintf(" ×%s", formatNumber(quantity))
+		}
+		return text
 	}
-	md.WriteString("\n")
+	return ""
+}
 
-	return md.String(), nil
+// writeItemStats writes the weapon and armor stats of an item, such as its damage, properties and armor class
+func writeItemStats(md *strings.Builder, item Item, definitions *ItemDefinitions) {
+	// Damage
+	if item.Dmg1 != "" {
+		damage := item.Dmg1
+		if item.DmgType != "" {
+			damage += " " + getDamageTypeName(item.DmgType)
+		}
+		md.WriteString(fmt.Sprintf("**Damage:** %s\n\n", damage))
+	}
+
+	// Armor class depends on the type of armor, e.g. light armor adds the full Dexterity modifier
+	if item.AC > 0 {
+		itemType, _ := parseReference(item.Type)
+		switch itemType {
+		case "LA":
+			md.WriteString(fmt.Sprintf("**Armor Class:** %d + Dex modifier\n\n", item.AC))
+		case "MA":
+			md.WriteString(fmt.Sprintf("**Armor Class:** %d + Dex modifier (max 2)\n\n", item.AC))
+		case "S":
+			md.WriteString(fmt.Sprintf("**Armor Class:** +%d\n\n", item.AC))
+		default:
+			md.WriteString(fmt.Sprintf("**Armor Class:** %d\n\n", item.AC))
+		}
+	}
+
+	// Properties, with the details some of them need, e.g. "Versatile (1d10)"
+	if len(item.Property) > 0 {
+		hasRange := false
+		properties := make([]string, 0, len(item.Property))
+		for _, property := range item.Property {
+			reference, note := getItemReference(property)
+			abbreviation, _ := parseReference(reference)
+			name := definitions.propertyName(reference)
+			text := fmt.Sprintf("[[%s|%s]]", safeFileName(definitionNoteName(name, itemPropertyKind)), strings.ToLower(name))
+
+			switch strings.ToUpper(abbreviation) {
+			case "V":
+				if item.Dmg2 != "" {
+					text += fmt.Sprintf(" (%s)", item.Dmg2)
+				}
+			case "T", "A", "AF":
+				if item.Range != "" {
+					text += fmt.Sprintf(" (range %s ft.)", item.Range)
+					hasRange = true
+				}
+			}
+			if note != "" {
+				text += fmt.Sprintf(" (%s)", processSpecialFormatting(note))
+			}
+			properties = append(properties, text)
+		}
+		md.WriteString(fmt.Sprintf("**Properties:** %s\n\n", strings.Join(properties, ", ")))
+
+		if item.Range != "" && !hasRange {
+			md.WriteString(fmt.Sprintf("**Range:** %s ft.\n\n", item.Range))
+		}
+	} else if item.Range != "" {
+		md.WriteString(fmt.Sprintf("**Range:** %s ft.\n\n", item.Range))
+	}
+
+	// Weapon mastery
+	if len(item.Mastery) > 0 {
+		masteries := make([]string, 0, len(item.Mastery))
+		for _, mastery := range item.Mastery {
+			reference, note := getItemReference(mastery)
+			name := definitions.masteryName(reference)
+			text := fmt.Sprintf("[[%s|%s]]", safeFileName(definitionNoteName(name, weaponMasteryKind)), name)
+			if note != "" {
+				text += fmt.Sprintf(" (%s)", processSpecialFormatting(note))
+			}
+			masteries = append(masteries, text)
+		}
+		md.WriteString(fmt.Sprintf("**Mastery:** %s\n\n", strings.Join(masteries, ", ")))
+	}
+
+	// Armor requirements
+	if item.Strength != nil {
+		switch strength := item.Strength.(type) {
+		case string:
+			if strength != "" {
+				md.WriteString(fmt.Sprintf("**Strength:** %s\n\n", strength))
+			}
+		case float64:
+			md.WriteString(fmt.Sprintf("**Strength:** %d\n\n", int(strength)))
+		}
+	}
+	if item.Stealth {
+		md.WriteString("**Stealth:** Disadvantage\n\n")
+	}
 }
 
-// Use the existing processSpecialFormatting function from spells.go
+// writeItemProperties writes the magic item properties of an item, such as its charges, bonuses and attached spells
+func writeItemProperties(md *strings.Builder, item Item) {
+	if item.Tier != "" {
+		md.WriteString(fmt.Sprintf("**Tier:** %s\n\n", titleCase(item.Tier)))
+	}
+
+	// Charges
+	if item.Charges != nil {
+		charges := formatCharges(item.Charges)
+		if recharge := formatRecharge(item.Recharge); recharge != "" {
+			if item.RechargeAmount != nil {
+				charges += fmt.Sprintf(" (regains %s %s)", formatCharges(item.RechargeAmount), recharge)
+			} else {
+				charges += fmt.Sprintf(" (regains all %s)", recharge)
+			}
+		}
+		md.WriteString(fmt.Sprintf("**Charges:** %s\n\n", charges))
+	} else if recharge := formatRecharge(item.Recharge); recharge != "" {
+		md.WriteString(fmt.Sprintf("**Recharge:** %s\n\n", sentenceCase(recharge)))
+	}
+
+	// Bonuses
+	var bonuses []string
+	if item.BonusWeapon != "" {
+		bonuses = append(bonuses, fmt.Sprintf("%s to attack and damage rolls", item.BonusWeapon))
+	}
+	if item.BonusAC != "" {
+		bonuses = append(bonuses, fmt.Sprintf("%s to AC", item.BonusAC))
+	}
+	if item.BonusSpellAttack != "" {
+		bonuses = append(bonuses, fmt.Sprintf("%s to spell attack rolls", item.BonusSpellAttack))
+	}
+	if item.BonusSavingThrow != "" {
+		bonuses = append(bonuses, fmt.Sprintf("%s to saving throws", item.BonusSavingThrow))
+	}
+	if len(bonuses) > 0 {
+		md.WriteString(fmt.Sprintf("**Bonus:** %s\n\n", strings.Join(bonuses, ", ")))
+	}
+
+	// Attached spells
+	if spells := getAttachedSpells(item.AttachedSpells); len(spells) > 0 {
+		links := make([]string, 0, len(spells))
+		for _, spell := range spells {
+			links = append(links, linkToReference(spell))
+		}
+		md.WriteString(fmt.Sprintf("**Spells:** %s\n\n", strings.Join(links, ", ")))
+	}
+
+	// Spellcasting focus
+	switch focus := item.Focus.(type) {
+	case bool:
+		if focus {
+			md.WriteString("**Spellcasting Focus:** Yes\n\n")
+		}
+	case []interface{}:
+		classes := make([]string, 0, len(focus))
+		for _, class := range focus {
+			if name, ok := class.(string); ok {
+				classes = append(classes, name)
+			}
+		}
+		md.WriteString(fmt.Sprintf("**Spellcasting Focus:** %s\n\n", strings.Join(classes, ", ")))
+	}
+
+	if item.Sentient {
+		md.WriteString("**Sentient:** Yes\n\n")
+	}
+	if item.Curse {
+		md.WriteString("**Cursed:** Yes\n\n")
+	}
+
+	// Loot tables the item can be rolled on
+	if len(item.LootTables) > 0 {
+		links := make([]string, 0, len(item.LootTables))
+		for _, table := range item.LootTables {
+			links = append(links, linkTo(table))
+		}
+		md.WriteString(fmt.Sprintf("**Loot Tables:** %s\n\n", strings.Join(links, ", ")))
+	}
+}
+
+// formatCharges formats a number of charges, which can be a number or a dice expression such as "{@dice 1d6 + 1}"
+func formatCharges(charges interface{}) string {
+	switch c := charges.(type) {
+	case float64:
+		return formatNumber(c)
+	case string:
+		return processSpecialFormatting(c)
+	}
+	return fmt.Sprintf("%v", charges)
+}
+
+// formatRecharge returns when an item regains its charges, e.g. "dawn" -> "daily at dawn"
+func formatRecharge(recharge string) string {
+	switch recharge {
+	case "":
+		return ""
+	case "dawn", "dusk", "midnight":
+		return "daily at " + recharge
+	case "decade":
+		return "every decade"
+	case "restShort":
+		return "after a short rest"
+	case "restLong":
+		return "after a long rest"
+	case "special":
+		return "as described"
+	default:
+		return recharge
+	}
+}
+
+// getAttachedSpells returns the spell references attached to an item. Older data uses a plain list, while newer
+// data groups the spells by how they're cast, e.g. {"daily": {"1e": ["fireball"]}, "will": ["light"]}.
+func getAttachedSpells(attachedSpells interface{}) []string {
+	var spells []string
+	switch a := attachedSpells.(type) {
+	case string:
+		spells = append(spells, a)
+	case []interface{}:
+		for _, spell := range a {
+			spells = append(spells, getAttachedSpells(spell)...)
+		}
+	case map[string]interface{}:
+		// Sort the keys so the spells are always listed in the same order
+		keys := make([]string, 0, len(a))
+		for key := range a {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			spells = append(spells, getAttachedSpells(a[key])...)
+		}
+	}
+	return spells
+}
+
+// formatCoins formats an amount in copper pieces using the largest coin it can be paid in exactly, e.g.
+// 1500 -> "15 gp" and 5 -> "5 cp". Platinum is only used for whole multiples of 1,000 gp, e.g. 100 000 ->
+// "100 pp" and 5 000 000 -> "5,000 pp", so a price such as 1,500 gp stays in gp.
+func formatCoins(copper float64) string {
+	switch {
+	case copper >= 100000 && copper == float64(int64(copper/100000))*100000:
+		return formatNumber(copper/1000) + " pp"
+	case copper >= 100 && copper == float64(int64(copper/100))*100:
+		return formatNumber(copper/100) + " gp"
+	case copper >= 10 && copper == float64(int64(copper/10))*10:
+		return formatNumber(copper/10) + " sp"
+	default:
+		return formatNumber(copper) + " cp"
+	}
+}
+
+// estimateItemValue returns the price range the Dungeon Master's Guide suggests for a magic item of the given
+// rarity, or an empty string if it suggests none
+func estimateItemValue(rarity string) string {
+	switch strings.ToLower(rarity) {
+	case "common":
+		return "50–100 gp"
+	case "uncommon":
+		return "101–500 gp"
+	case "rare":
+		return "501–5,000 gp"
+	case "very rare":
+		return "5,001–50,000 gp"
+	case "legendary":
+		return "50,001+ gp"
+	default:
+		return ""
+	}
+}
+
+// formatItemType returns the human-readable type of an item, e.g. "M" with the martial weapon category
+// -> "martial melee weapon". Specific variants name their base item, e.g. "melee weapon (longsword)".
+func formatItemType(item Item, definitions *ItemDefinitions) string {
+	var itemType string
+	if item.Type != "" {
+		itemType = strings.ToLower(definitions.typeName(item.Type))
+	}
+	if item.WeaponCategory != "" && itemType != "" {
+		itemType = strings.ToLower(item.WeaponCategory) + " " + itemType
+	}
+	if item.Wondrous {
+		if itemType == "" {
+			itemType = "wondrous item"
+		} else {
+			itemType = "wondrous item, " + itemType
+		}
+	}
+	if item.BaseItem != "" && itemType != "" {
+		baseName, _ := parseReference(item.BaseItem)
+		itemType += fmt.Sprintf(" (%s)", strings.ToLower(baseName))
+	}
+	return itemType
+}
+
+// formatRarity normalizes an item rarity as it is written in the books. Mundane items have no rarity.
+func formatRarity(rarity string) string {
+	switch strings.ToLower(rarity) {
+	case "", "none", "unknown":
+		return ""
+	case "unknown (magic)":
+		return "rarity unknown"
+	case "varies":
+		return "rarity varies"
+	default:
+		return strings.ToLower(rarity)
+	}
+}
+
+// sentenceCase capitalizes the first letter of a text
+func sentenceCase(text string) string {
+	for i, r := range text {
+		return string(unicode.ToUpper(r)) + text[i+len(string(r)):]
+	}
+	return text
+}
+
+// getItemReference returns the reference and optional note of an item property or mastery,
+// which can be a plain reference such as "V|XPHB" or an object such as {"uid": "V|XPHB", "note": "..."}
+func getItemReference(value interface{}) (string, string) {
+	switch v := value.(type) {
+	case string:
+		return v, ""
+	case map[string]interface{}:
+		reference, _ := v["uid"].(string)
+		note, _ := v["note"].(string)
+		return reference, note
+	}
+	return "", ""
+}
+
+// getDamageTypeName returns the full name of a damage type from its abbreviation
+func getDamageTypeName(damageType string) string {
+	switch damageType {
+	case "A":
+		return "acid"
+	case "B":
+		return "bludgeoning"
+	case "C":
+		return "cold"
+	case "F":
+		return "fire"
+	case "O":
+		return "force"
+	case "L":
+		return "lightning"
+	case "N":
+		return "necrotic"
+	case "P":
+		return "piercing"
+	case "I":
+		return "poison"
+	case "Y":
+		return "psychic"
+	case "R":
+		return "radiant"
+	case "S":
+		return "slashing"
+	case "T":
+		return "thunder"
+	default:
+		return damageType
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 )
 
@@ -36,7 +37,7 @@ func TestItemToMarkdown(t *testing.T) {
 					},
 				},
 			},
-			expected: "# Robe of the Archmagi\n\n*legendary*\n\n*Requires attunement by a sorcerer, warlock, or wizard*\n\nThis elegant garment is made from exquisite cloth of white, gray, or black and adorned with silvery runes. The robe's color corresponds to the alignment for which the item was created. A white robe was made for good, gray for neutral, and black for evil. You can't attune to a robe of the archmagi that doesn't correspond to your alignment.\n\nYou gain these benefits while wearing the robe:\n\n- If you aren't wearing armor, your base Armor Class is 15 + your Dexterity modifier.\n- You have advantage on saving throws against spell and other magical effects.\n- Your spell save DC and spell attack bonus each increase by 2.\n\n**Source:** DMG, page 194\n",
+			expected: "# Robe of the Archmagi\n\n*Legendary*\n\n*Requires attunement by a sorcerer, warlock, or wizard*\n\nThis elegant garment is made from exquisite cloth of white, gray, or black and adorned with silvery runes. The robe's color corresponds to the alignment for which the item was created. A white robe was made for good, gray for neutral, and black for evil. You can't attune to a robe of the archmagi that doesn't correspond to your alignment.\n\nYou gain these benefits while wearing the robe:\n\n- If you aren't wearing armor, your base Armor Class is 15 + your Dexterity modifier.\n- You have advantage on saving throws against spell and other magical effects.\n- Your spell save DC and spell attack bonus each increase by 2.\n\n**Source:** DMG, page 194\n",
 		},
 		{
 			name: "Basic item",
@@ -45,14 +46,14 @@ func TestItemToMarkdown(t *testing.T) {
 				Type:   "Weapon",
 				Rarity: "Common",
 				Weight: 3.0,
-				Value:  float64(15),
+				Value:  float64(1500), // Values are in copper pieces
 				Source: "PHB",
 				Page:   149,
 				Entries: []interface{}{
 					"A versatile weapon that can be used with one or two hands.",
 				},
 			},
-			expected: "# Longsword\n\n*Weapon, Common*\n\n**Weight:** 3.0 lb.\n\n**Value:** 15 gp\n\nA versatile weapon that can be used with one or two hands.\n\n**Source:** PHB, page 149\n",
+			expected: "# Longsword\n\n*Weapon, common*\n\n**Weight:** 3.0 lb.\n\n**Value:** 15 gp\n\nA versatile weapon that can be used with one or two hands.\n\n**Source:** PHB, page 149\n",
 		},
 		{
 			name: "Magic item with attunement",
@@ -68,7 +69,7 @@ func TestItemToMarkdown(t *testing.T) {
 					"You gain a +1 bonus to AC and saving throws while wearing this ring.",
 				},
 			},
-			expected: "# Ring of Protection\n\n*Ring, Rare*\n\n*Requires attunement*\n\n**Weight:** 0.1 lb.\n\nYou gain a +1 bonus to AC and saving throws while wearing this ring.\n\n**Source:** DMG, page 191\n",
+			expected: "# Ring of Protection\n\n*Ring, rare*\n\n*Requires attunement*\n\n**Weight:** 0.1 lb.\n\nYou gain a +1 bonus to AC and saving throws while wearing this ring.\n\n**Source:** DMG, page 191\n",
 		},
 		{
 			name: "Item with complex value",
@@ -106,13 +107,76 @@ func TestItemToMarkdown(t *testing.T) {
 					},
 				},
 			},
-			expected: "# Bag of Holding\n\n*Wondrous Item, Uncommon*\n\n**Weight:** 15.0 lb.\n\nThis bag has an interior space considerably larger than its outside dimensions.\n\n- The bag can hold up to 500 pounds.\n- The bag weighs 15 pounds, regardless of its contents.\n- Retrieving an item from the bag requires an action.\n\n**Source:** DMG, page 153\n",
+			expected: "# Bag of Holding\n\n*Wondrous item, uncommon*\n\n**Weight:** 15.0 lb.\n\nThis bag has an interior space considerably larger than its outside dimensions.\n\n- The bag can hold up to 500 pounds.\n- The bag weighs 15 pounds, regardless of its contents.\n- Retrieving an item from the bag requires an action.\n\n**Source:** DMG, page 153\n",
+		},
+		{
+			name: "Item with table and nested sections",
+			item: Item{
+				Name:     "Bag of Tricks",
+				Wondrous: true,
+				Rarity:   "uncommon",
+				Source:   "DMG",
+				Page:     154,
+				Entries: []interface{}{
+					"This ordinary bag appears empty.",
+					map[string]interface{}{
+						"type":      "table",
+						"colLabels": []interface{}{"d8", "Creature"},
+						"rows": []interface{}{
+							[]interface{}{"1", "{@creature Weasel}"},
+							[]interface{}{"2", "{@creature Giant rat}"},
+						},
+					},
+					map[string]interface{}{
+						"type":    "entries",
+						"name":    "Variants",
+						"entries": []interface{}{"Other bags exist."},
+					},
+					map[string]interface{}{
+						"type":    "inset",
+						"entries": []interface{}{"The creature vanishes at the next dawn."},
+					},
+				},
+			},
+			expected: "# Bag of Tricks\n\n*Wondrous item, uncommon*\n\nThis ordinary bag appears empty.\n\n| d8 | Creature |\n| --- | --- |\n| 1 | Weasel |\n| 2 | Giant rat |\n\n## Variants\n\nOther bags exist.\n\n> The creature vanishes at the next dawn.\n\n**Source:** DMG, page 154\n",
+		},
+		{
+			name: "Weapon with stats",
+			item: Item{
+				Name:           "Longsword",
+				Type:           "M",
+				Rarity:         "none",
+				Weight:         3.0,
+				WeaponCategory: "martial",
+				Dmg1:           "1d8",
+				Dmg2:           "1d10",
+				DmgType:        "S",
+				Property:       []interface{}{"V"},
+				Mastery:        []interface{}{"Sap|XPHB"},
+				Source:         "PHB",
+				Page:           149,
+			},
+			expected: "# Longsword\n\n*Martial melee weapon*\n\n**Weight:** 3.0 lb.\n\n**Damage:** 1d8 slashing\n\n**Properties:** [[Versatile (Item Property)|versatile]] (1d10)\n\n**Mastery:** [[Sap (Weapon Mastery)|Sap]]\n\n**Source:** PHB, page 149\n",
+		},
+		{
+			name: "Armor with requirements",
+			item: Item{
+				Name:     "Plate Armor",
+				Type:     "HA",
+				Rarity:   "none",
+				AC:       18,
+				Strength: "15",
+				Stealth:  true,
+				Source:   "PHB",
+				Page:     145,
+			},
+			expected: "# Plate Armor\n\n*Heavy armor*\n\n**Armor Class:** 18\n\n**Strength:** 15\n\n**Stealth:** Disadvantage\n\n**Source:** PHB, page 145\n",
 		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := itemToMarkdown(tt.item)
+			result, err := itemToMarkdown(tt.item, nil)
 			if err != nil {
 				t.Fatalf("itemToMarkdown() error = %v", err)
 			}
@@ -123,6 +187,342 @@ func TestItemToMarkdown(t *testing.T) {
 	}
 }
 
+func TestItemToMarkdown_MagicProperties(t *testing.T) {
+	item := Item{
+		Name:           "Wand of Fireballs",
+		Type:           "WD|DMG",
+		Rarity:         "rare",
+		Charges:        float64(7),
+		Recharge:       "dawn",
+		RechargeAmount: "{@dice 1d6 + 1}",
+		AttachedSpells: []interface{}{"fireball"},
+		Focus:          []interface{}{"Sorcerer", "Warlock", "Wizard"},
+		LootTables:     []string{"Magic Item Table F"},
+		Source:         "DMG",
+		Page:           210,
+	}
+
+	result, err := itemToMarkdown(item, nil)
+	if err != nil {
+		t.Fatalf("itemToMarkdown() error = %v", err)
+	}
+
+	expected := "---\ncharges: 7\nrecharge: dawn\n---\n\n" +
+		"# Wand of Fireballs\n\n" +
+		"*Wand, rare*\n\n" +
+		"**Charges:** 7 (regains 1d6 + 1 daily at dawn)\n\n" +
+		"**Spells:** [[Fireball]]\n\n" +
+		"**Spellcasting Focus:** Sorcerer, Warlock, Wizard\n\n" +
+		"**Loot Tables:** [[Magic Item Table F]]\n\n" +
+		"**Source:** DMG, page 210\n"
+	if result != expected {
+		t.Errorf("itemToMarkdown() = %q, want %q", result, expected)
+	}
+}
+
+func TestWriteItemProperties(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     Item
+		expected string
+	}{
+		{
+			name:     "Bonuses",
+			item:     Item{BonusAC: "+1", BonusSavingThrow: "+1"},
+			expected: "**Bonus:** +1 to AC, +1 to saving throws\n\n",
+		},
+		{
+			name:     "Tier, sentience and curse",
+			item:     Item{Tier: "major", Sentient: true, Curse: true},
+			expected: "**Tier:** Major\n\n**Sentient:** Yes\n\n**Cursed:** Yes\n\n",
+		},
+		{
+			name:     "Charges without recharge amount",
+			item:     Item{Charges: float64(3), Recharge: "restLong"},
+			expected: "**Charges:** 3 (regains all after a long rest)\n\n",
+		},
+		{
+			name: "Spells grouped by usage",
+			item: Item{AttachedSpells: map[string]interface{}{
+				"will":  []interface{}{"light|XPHB"},
+				"daily": map[string]interface{}{"1e": []interface{}{"misty step|XPHB"}},
+			}},
+			expected: "**Spells:** [[Misty Step]], [[Light]]\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var md strings.Builder
+			writeItemProperties(&md, tt.item)
+			if md.String() != tt.expected {
+				t.Errorf("writeItemProperties() = %q, want %q", md.String(), tt.expected)
+			}
+		})
+	}
+}
+
+func TestItemToMarkdown_GroupsAndPacks(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     Item
+		expected string
+	}{
+		{
+			name: "Item group",
+			item: Item{
+				Name:   "Arcane Focus",
+				Type:   "SCF",
+				Items:  []string{"Crystal|PHB", "Orb|PHB"},
+				Source: "PHB",
+			},
+			expected: "## Items\n\n- [[Crystal]]\n- [[Orb]]\n\n",
+		},
+		{
+			name: "Pack contents",
+			item: Item{
+				Name: "Explorer's Pack",
+				Type: "G",
+				PackContents: []interface{}{
+					"backpack|phb",
+					map[string]interface{}{"item": "torch|phb", "quantity": float64(10)},
+					map[string]interface{}{"special": "a small knife"},
+				},
+				Source: "PHB",
+			},
+			expected: "## Contents\n\n- [[Backpack]]\n- [[Torch]] ×10\n- a small knife\n\n",
+		},
+		{
+			name:     "Group member",
+			item:     Item{Name: "Orb", Type: "SCF", MemberOf: []string{"Arcane Focus"}, Source: "PHB"},
+			expected: "**Part of:** [[Arcane Focus]]\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := itemToMarkdown(tt.item, nil)
+			if err != nil {
+				t.Fatalf("itemToMarkdown() error = %v", err)
+			}
+			if !strings.Contains(result, tt.expected) {
+				t.Errorf("itemToMarkdown() missing %q, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestNewItemMemberships(t *testing.T) {
+	itemFile := ItemFile{
+		ItemGroup: []Item{{Name: "Arcane Focus", Items: []string{"Orb|PHB", "Crystal|PHB"}}},
+		Item: []Item{{Name: "Explorer's Pack", PackContents: []interface{}{
+			map[string]interface{}{"item": "torch|phb", "quantity": float64(10)},
+		}}},
+	}
+	baseItemFile := ItemFile{
+		BaseItem: []Item{{Name: "Burglar's Pack", PackContents: []interface{}{"torch|phb"}}},
+	}
+
+	memberships := newItemMemberships(itemFile, baseItemFile)
+
+	if got := strings.Join(memberships["orb"], ","); got != "Arcane Focus" {
+		t.Errorf("memberships[orb] = %q, want %q", got, "Arcane Focus")
+	}
+	if got := strings.Join(memberships["torch"], ","); got != "Explorer's Pack,Burglar's Pack" {
+		t.Errorf("memberships[torch] = %q, want %q", got, "Explorer's Pack,Burglar's Pack")
+	}
+}
+
+func TestFormatCoins(t *testing.T) {
+	tests := []struct {
+		copper   float64
+		expected string
+	}{
+		{5000, "50 gp"},
+		{1500, "15 gp"},
+		{50, "5 sp"},
+		{5, "5 cp"},
+		{150, "15 sp"},
+		{1, "1 cp"},
+		{1000, "10 gp"},
+		{150000, "1,500 gp"}, // Not a whole multiple of 1,000 gp
+		{100000, "100 pp"},
+		{5000000, "5,000 pp"}, // Thousands separators
+		{2400000, "2,400 pp"},
+	}
+
+	for _, test := range tests {
+		if result := formatCoins(test.copper); result != test.expected {
+			t.Errorf("formatCoins(%v) = %q; want %q", test.copper, result, test.expected)
+		}
+	}
+}
+
+func TestItemToMarkdown_ValueEstimate(t *testing.T) {
+	tests := []struct {
+		name     string
+		item     Item
+		expected string
+	}{
+		{
+			name:     "Estimate without value",
+			item:     Item{Name: "Cloak of Elvenkind", Rarity: "uncommon", ValueEstimate: estimateItemValue("uncommon")},
+			expected: "**Value:** 101–500 gp (estimated)\n\n",
+		},
+		{
+			name:     "Value takes precedence over estimate",
+			item:     Item{Name: "Potion of Healing", Rarity: "common", Value: float64(5000), ValueEstimate: estimateItemValue("common")},
+			expected: "**Value:** 50 gp\n\n",
+		},
+		{
+			name:     "Value in platinum",
+			item:     Item{Name: "Platinum Piece", Value: map[string]interface{}{"quantity": float64(1000), "unit": "pp"}},
+			expected: "**Value:** 1,000 pp\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := itemToMarkdown(tt.item, nil)
+			if err != nil {
+				t.Fatalf("itemToMarkdown() error = %v", err)
+			}
+			if !strings.Contains(result, tt.expected) {
+				t.Errorf("itemToMarkdown() missing %q, got %s", tt.expected, result)
+			}
+		})
+	}
+
+	if estimate := estimateItemValue("none"); estimate != "" {
+		t.Errorf("estimateItemValue(none) = %q, want no estimate", estimate)
+	}
+}
+
+func TestFormatItemType(t *testing.T) {
+	definitions := newItemDefinitions(ItemFile{
+		ItemType: []ItemTypeDefinition{{Abbreviation: "SCF", Source: "XPHB", Name: "Spellcasting Focus"}},
+	})
+
+	tests := []struct {
+		name     string
+		item     Item
+		expected string
+	}{
+		{"Type with source", Item{Type: "SCF|XPHB"}, "spellcasting focus"},
+		{"Type without definition", Item{Type: "RG"}, "ring"},
+		{"Coinage", Item{Type: "$C"}, "treasure (coinage)"},
+		{"Weapon category", Item{Type: "R", WeaponCategory: "simple"}, "simple ranged weapon"},
+		{"Wondrous item", Item{Wondrous: true}, "wondrous item"},
+		{"Specific variant", Item{Type: "HA", BaseItem: "Plate Armor|PHB"}, "heavy armor (plate armor)"},
+		{"No type", Item{}, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatItemType(tt.item, definitions); result != tt.expected {
+				t.Errorf("formatItemType() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatRarity(t *testing.T) {
+	tests := []struct {
+		rarity   string
+		expected string
+	}{
+		{"none", ""},
+		{"unknown", ""},
+		{"unknown (magic)", "rarity unknown"},
+		{"varies", "rarity varies"},
+		{"artifact", "artifact"},
+		{"Very Rare", "very rare"},
+	}
+
+	for _, test := range tests {
+		if result := formatRarity(test.rarity); result != test.expected {
+			t.Errorf("formatRarity(%q) = %q; want %q", test.rarity, result, test.expected)
+		}
+	}
+}
+
+func TestFluffImagePaths(t *testing.T) {
+	images := []interface{}{
+		map[string]interface{}{"type": "image", "href": map[string]interface{}{"type": "internal", "path": "items/DMG/Bag of Holding.webp"}},
+		map[string]interface{}{"type": "image", "href": map[string]interface{}{"type": "external", "url": "https://example.com/bag.png"}},
+	}
+
+	tests := []struct {
+		name           string
+		imageDirectory string
+		expected       []string
+	}{
+		{
+			name:           "With image directory",
+			imageDirectory: "img",
+			expected:       []string{"img/items/DMG/Bag of Holding.webp", "https://example.com/bag.png"},
+		},
+		{
+			name:           "Without image directory",
+			imageDirectory: "",
+			expected:       []string{"https://example.com/bag.png"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := fluffImagePaths(images, tt.imageDirectory)
+			if strings.Join(result, ",") != strings.Join(tt.expected, ",") {
+				t.Errorf("fluffImagePaths() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestItemToMarkdown_Fluff(t *testing.T) {
+	item := Item{
+		Name:           "Bag of Holding",
+		Type:           "Wondrous item",
+		Rarity:         "uncommon",
+		Source:         "DMG",
+		HasFluff:       true,
+		HasFluffImages: true,
+		Entries:        []interface{}{"This bag has an interior space considerably larger than its outside dimensions."},
+	}
+	fluff := &Fluff{
+		Name:    "Bag of Holding",
+		Source:  "DMG",
+		Entries: []interface{}{"Wizards prize these bags above all others."},
+		Images: []interface{}{
+			map[string]interface{}{"type": "image", "href": map[string]interface{}{"type": "internal", "path": "items/DMG/Bag of Holding.webp"}},
+		},
+	}
+	applyItemFluff(&item, fluff, "img")
+
+	result, err := itemToMarkdown(item, nil)
+	if err != nil {
+		t.Fatalf("itemToMarkdown() error = %v", err)
+	}
+
+	expected := "# Bag of Holding\n\n" +
+		"*Wondrous item, uncommon*\n\n" +
+		"![[img/items/DMG/Bag of Holding.webp]]\n\n" +
+		"This bag has an interior space considerably larger than its outside dimensions.\n\n" +
+		"## Lore\n\n" +
+		"Wizards prize these bags above all others.\n\n" +
+		"**Source:** DMG\n"
+	if result != expected {
+		t.Errorf("itemToMarkdown() = %q, want %q", result, expected)
+	}
+
+	// Without the flags the fluff is ignored
+	plain := Item{Name: "Bag of Holding", Source: "DMG"}
+	applyItemFluff(&plain, fluff, "img")
+	if plain.Lore != nil || plain.Images != nil {
+		t.Errorf("applyItemFluff() applied fluff to an item without hasFluff, got %v and %v", plain.Lore, plain.Images)
+	}
+}
+
 func TestParseItems_WithMockData(t *testing.T) {
 	// Create temporary directories for test
 	tempDir, err := os.MkdirTemp("", "items-test")
@@ -149,7 +549,7 @@ func TestParseItems_WithMockData(t *testing.T) {
 				Type:   "Weapon",
 				Rarity: "Common",
 				Weight: 3.0,
-				Value:  float64(15),
+				Value:  float64(1500), // Values are in copper pieces
 				Source: "PHB",
 				Page:   149,
 				Entries: []interface{}{
@@ -208,7 +608,7 @@ func TestParseItems_WithMockData(t *testing.T) {
 
 	// Run the parser
 	ctx := context.Background()
-	if err := parseItems(ctx, dataDir, outDir); err != nil {
+	if err := parseItems(ctx, dataDir, outDir, "", "", nil, false); err != nil {
 		t.Fatalf("parseItems() error = %v", err)
 	}
 
@@ -242,8 +642,53 @@ func TestParseItems_WithMockData(t *testing.T) {
 		t.Fatalf("Failed to read Longsword.md: %v", err)
 	}
 
-	expectedContent := "# Longsword\n\n*Weapon, Common*\n\n**Weight:** 3.0 lb.\n\n**Value:** 15 gp\n\nA versatile weapon that can be used with one or two hands.\n\n**Source:** PHB, page 149\n"
+	expectedContent := "# Longsword\n\n*Weapon, common*\n\n**Weight:** 3.0 lb.\n\n**Value:** 15 gp\n\nA versatile weapon that can be used with one or two hands.\n\n**Source:** PHB, page 149\n"
 	if string(longswordContent) != expectedContent {
 		t.Errorf("Longsword.md content = %v, want %v", string(longswordContent), expectedContent)
 	}
 }
+
+func TestParseItems_WithFluff(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "items-fluff-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	files := map[string]interface{}{
+		"items.json": ItemFile{
+			Item: []Item{{Name: "Bag of Holding", Type: "Wondrous item", Source: "DMG", HasFluff: true}},
+		},
+		"items-base.json": ItemFile{},
+		"fluff-items.json": map[string]interface{}{
+			"itemFluff": []Fluff{{Name: "Bag of Holding", Source: "DMG", Entries: []interface{}{"Wizards prize these bags above all others."}}},
+		},
+	}
+	for name, content := range files {
+		data, err := json.Marshal(content)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dataDir, name), data, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	if err := parseItems(context.Background(), dataDir, outDir, "img", "", nil, false); err != nil {
+		t.Fatalf("parseItems() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outDir, "items", "Bag of Holding.md"))
+	if err != nil {
+		t.Fatalf("Failed to read Bag of Holding.md: %v", err)
+	}
+	if !strings.Contains(string(content), "## Lore\n\nWizards prize these bags above all others.") {
+		t.Errorf("Bag of Holding.md missing fluff, got %s", content)
+	}
+}
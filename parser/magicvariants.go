@@ -0,0 +1,387 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// MagicVariantFile represents the structure of the magicvariants.json file
+type MagicVariantFile struct {
+	MagicVariant []MagicVariant `json:"magicvariant"`
+}
+
+// MagicVariant represents a generic magic item such as "+1 Weapon" that applies to many base items
+type MagicVariant struct {
+	Name        string                   `json:"name"`
+	Type        string                   `json:"type,omitempty"`
+	Requires    []map[string]interface{} `json:"requires,omitempty"` // A base item must match every field of at least one entry
+	Excludes    map[string]interface{}   `json:"excludes,omitempty"` // A base item matching any field is excluded
+	Inherits    map[string]interface{}   `json:"inherits,omitempty"` // Properties passed on to each specific item
+	Entries     []interface{}            `json:"entries,omitempty"`
+	ReprintedAs []interface{}            `json:"reprintedAs,omitempty"`
+}
+
+// templatePattern matches the {=field} and {=field/modifier} placeholders used in inherited entries
+var templatePattern = regexp.MustCompile(`\{=([a-zA-Z]+)(?:/([a-z]+))?\}`)
+
+// parseMagicVariants parses the generic magic variants and the base items they apply to, and writes a note per
+// generic variant. Unless grouped is set, a note is also written for every specific item, e.g. "+1 Longsword".
+// The edition decides which printings of reprinted variants and base items are used, and the filter which
+// sources are.
+func parseMagicVariants(ctx context.Context, dataDirectory, outDirectory, edition string, filter *sourceFilter, grouped, estimateValues bool) error {
+	// Magic variants are optional, so a missing file is not an error
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "magicvariants.json"))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read magic variant file: %w", err)
+	}
+
+	var variantFile MagicVariantFile
+	if err := json.Unmarshal(fileData, &variantFile); err != nil {
+		return fmt.Errorf("failed to parse magic variant file: %w", err)
+	}
+
+	baseItems, baseItemFile, err := readBaseItems(dataDirectory)
+	if err != nil {
+		return err
+	}
+	definitions := newItemDefinitions(baseItemFile)
+
+	reprints := newReprintFilter(edition, filter)
+	for _, baseItem := range baseItems {
+		source, _ := baseItem["source"].(string)
+		reprintedAs, _ := baseItem["reprintedAs"].([]interface{})
+		reprints.add(source, reprintedAs)
+	}
+	for _, variant := range variantFile.MagicVariant {
+		variantSource, _ := variant.Inherits["source"].(string)
+		reprints.add(variantSource, variant.ReprintedAs)
+	}
+
+	// Create output directory if it doesn't exist
+	outDir := filepath.Join(outDirectory, "items")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Process each magic variant
+	for _, variant := range variantFile.MagicVariant {
+		variantSource, _ := variant.Inherits["source"].(string)
+		if !filter.keep(variantSource) || !reprints.keep(variant.Name, variantSource, variant.ReprintedAs) {
+			continue
+		}
+		variantNoteName := reprints.noteName(variant.Name, variantSource, reprints.links(variant.ReprintedAs))
+
+		var specificItems []Item
+		for _, baseItem := range baseItems {
+			name, _ := baseItem["name"].(string)
+			source, _ := baseItem["source"].(string)
+			reprintedAs, _ := baseItem["reprintedAs"].([]interface{})
+			if !filter.keep(source) || !reprints.keep(name, source, reprintedAs) {
+				continue
+			}
+			if variantAppliesTo(variant, baseItem) {
+				item := specificVariantItem(variant, baseItem)
+
+				// Items made from an older printing of the variant or base item are named after those printings
+				var olderSources []string
+				if variantNoteName != variant.Name {
+					olderSources = append(olderSources, variantSource)
+				}
+				if reprints.noteName(name, source, reprints.links(reprintedAs)) != name {
+					olderSources = append(olderSources, source)
+				}
+				item.NoteName = specificItemNoteName(item.Name, olderSources)
+
+				specificItems = append(specificItems, item)
+			}
+		}
+
+		mdContent, err := magicVariantToMarkdown(variant, specificItems, !grouped, definitions)
+		if err != nil {
+			return fmt.Errorf("failed to convert magic variant to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, variantNoteName, mdContent); err != nil {
+			return err
+		}
+
+		if grouped {
+			continue
+		}
+
+		for _, item := range specificItems {
+			if estimateValues {
+				item.ValueEstimate = estimateItemValue(item.Rarity)
+			}
+
+			mdContent, err := itemToMarkdown(item, definitions)
+			if err != nil {
+				return fmt.Errorf("failed to convert item to markdown: %w", err)
+			}
+
+			if err := writeNote(outDir, item.NoteName, mdContent); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// readBaseItems reads items-base.json. The base items are kept as raw objects, since variant requirements can
+// match on any field, and the item file holds the item properties, types and masteries they refer to.
+func readBaseItems(dataDirectory string) ([]map[string]interface{}, ItemFile, error) {
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "items-base.json"))
+	if err != nil {
+		return nil, ItemFile{}, fmt.Errorf("failed to read base item file: %w", err)
+	}
+
+	var baseItemFile struct {
+		ItemFile
+		BaseItem []map[string]interface{} `json:"baseitem"` // Takes the place of ItemFile.BaseItem
+	}
+	if err := json.Unmarshal(fileData, &baseItemFile); err != nil {
+		return nil, ItemFile{}, fmt.Errorf("failed to parse base item file: %w", err)
+	}
+
+	return baseItemFile.BaseItem, baseItemFile.ItemFile, nil
+}
+
+// variantAppliesTo reports whether a magic variant can be applied to a base item
+func variantAppliesTo(variant MagicVariant, baseItem map[string]interface{}) bool {
+	for field, value := range variant.Excludes {
+		if itemFieldMatches(baseItem[field], value) {
+			return false
+		}
+	}
+
+	for _, requirement := range variant.Requires {
+		matches := true
+		for field, value := range requirement {
+			if !itemFieldMatches(baseItem[field], value) {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// itemFieldMatches reports whether an item field matches a requirement. A list of wanted values matches any
+// of them, a list field matches if it contains the wanted value, and types such as "M" match "M|XPHB".
+func itemFieldMatches(field, want interface{}) bool {
+	if field == nil {
+		return false
+	}
+	if wants, ok := want.([]interface{}); ok {
+		for _, w := range wants {
+			if itemFieldMatches(field, w) {
+				return true
+			}
+		}
+		return false
+	}
+	if fields, ok := field.([]interface{}); ok {
+		for _, f := range fields {
+			if itemFieldMatches(f, want) {
+				return true
+			}
+		}
+		return false
+	}
+	if fieldStr, ok := field.(string); ok {
+		if wantStr, ok := want.(string); ok {
+			if !strings.Contains(wantStr, "|") {
+				fieldStr, _ = parseReference(fieldStr)
+			}
+			return strings.EqualFold(fieldStr, wantStr)
+		}
+	}
+	return reflect.DeepEqual(field, want)
+}
+
+// specificVariantItem creates the specific item made by applying a magic variant to a base item,
+// e.g. "+1 Weapon" and "Longsword" make "+1 Longsword"
+func specificVariantItem(variant MagicVariant, baseItem map[string]interface{}) Item {
+	inherits := variant.Inherits
+	baseName, _ := baseItem["name"].(string)
+	baseSource, _ := baseItem["source"].(string)
+
+	name := baseName
+	if remove, ok := inherits["nameRemove"].(string); ok {
+		name = strings.ReplaceAll(name, remove, "")
+	}
+	prefix, _ := inherits["namePrefix"].(string)
+	suffix, _ := inherits["nameSuffix"].(string)
+
+	// The specific item starts as a copy of the base item, keeping its type, weight and weapon stats,
+	// and then takes on the inherited properties such as rarity, attunement, charges and bonuses
+	var item Item
+	decodeItemFields(&item, baseItem)
+	item.Page = 0
+	decodeItemFields(&item, inherits)
+	item.Name = prefix + name + suffix
+	item.Entries = nil
+	item.ReprintedAs = nil // Reprints of the base item aren't reprints of the specific item
+
+	// The base item's value only carries over when the variant scales it, e.g. "valueMult": 2
+	item.Value = inherits["value"]
+	if valueMult, ok := inherits["valueMult"].(float64); ok {
+		if baseValue, ok := baseItem["value"].(float64); ok {
+			item.Value = baseValue * valueMult
+		}
+	}
+	if weightMult, ok := inherits["weightMult"].(float64); ok {
+		item.Weight *= weightMult
+	}
+	item.BaseItem = baseName + "|" + baseSource
+	item.GenericVariant = &ItemReference{Name: variant.Name, Source: item.Source}
+
+	// The variant's entries come first, followed by those of the base item
+	if entries, ok := inherits["entries"].([]interface{}); ok {
+		item.Entries = append(item.Entries, applyVariantTemplates(entries, inherits, baseItem)...)
+	}
+	if entries, ok := baseItem["entries"].([]interface{}); ok {
+		item.Entries = append(item.Entries, entries...)
+	}
+
+	return item
+}
+
+// specificItemNoteName returns the note name of a specific item. When both editions are written, the items made
+// from older printings are named after them, e.g. "+1 Longsword (PHB)" or "+1 Longsword (DMG, PHB)", so they
+// don't overwrite the item made from the reprints.
+func specificItemNoteName(name string, olderSources []string) string {
+	if len(olderSources) == 0 {
+		return name
+	}
+	return fmt.Sprintf("%s (%s)", name, strings.Join(olderSources, ", "))
+}
+
+// decodeItemFields sets the fields of an item from a raw object, leaving fields it doesn't contain untouched.
+// Base items and inherited properties are read as raw objects for matching, so invalid fields are ignored.
+func decodeItemFields(item *Item, fields map[string]interface{}) {
+	if data, err := json.Marshal(fields); err == nil {
+		_ = json.Unmarshal(data, item)
+	}
+}
+
+// applyVariantTemplates fills in the {=field} placeholders in inherited entries, e.g. "{=bonusWeapon}" -> "+1",
+// using the inherited properties first and the base item second
+func applyVariantTemplates(entries []interface{}, inherits, baseItem map[string]interface{}) []interface{} {
+	result := make([]interface{}, 0, len(entries))
+	for _, entry := range entries {
+		switch e := entry.(type) {
+		case string:
+			result = append(result, applyVariantTemplate(e, inherits, baseItem))
+		case map[string]interface{}:
+			copied := make(map[string]interface{}, len(e))
+			for key, value := range e {
+				copied[key] = value
+			}
+			for _, key := range []string{"entries", "items"} {
+				if nested, ok := e[key].([]interface{}); ok {
+					copied[key] = applyVariantTemplates(nested, inherits, baseItem)
+				}
+			}
+			result = append(result, copied)
+		default:
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// applyVariantTemplate fills in the placeholders in a single string.
+// The modifiers l, u and t change the value to lower, upper or title case.
+func applyVariantTemplate(text string, inherits, baseItem map[string]interface{}) string {
+	return templatePattern.ReplaceAllStringFunc(text, func(match string) string {
+		parts := templatePattern.FindStringSubmatch(match)
+		field, modifier := parts[1], parts[2]
+
+		value, ok := inherits[field]
+		if !ok {
+			if field == "baseName" {
+				field = "name"
+			}
+			value, ok = baseItem[field]
+		}
+		if !ok {
+			return match
+		}
+
+		text := fmt.Sprintf("%v", value)
+		switch modifier {
+		case "l":
+			text = strings.ToLower(text)
+		case "u":
+			text = strings.ToUpper(text)
+		case "t":
+			text = titleCase(text)
+		}
+		return text
+	})
+}
+
+// magicVariantToMarkdown converts a generic magic variant to Markdown format, with a table of the specific
+// items it can be applied to. The items are linked when they have their own notes.
+func magicVariantToMarkdown(variant MagicVariant, specificItems []Item, linkItems bool, definitions *ItemDefinitions) (string, error) {
+	var md strings.Builder
+
+	generic := Item{Name: variant.Name, Type: "Generic Variant", Entries: variant.Entries}
+	if source, ok := variant.Inherits["source"].(string); ok {
+		generic.Source = source
+	}
+	if page, ok := variant.Inherits["page"].(float64); ok {
+		generic.Page = int(page)
+	}
+	if rarity, ok := variant.Inherits["rarity"].(string); ok {
+		generic.Rarity = rarity
+	}
+	if reqAttune, ok := variant.Inherits["reqAttune"]; ok {
+		generic.RequiresAttunement = reqAttune
+	}
+	writeItemDetails(&md, generic, definitions)
+
+	// Applicable base items
+	if len(specificItems) > 0 {
+		md.WriteString("## Items\n\n")
+		md.WriteString("| Item | Base Item |\n")
+		md.WriteString("| --- | --- |\n")
+		for _, item := range specificItems {
+			name := item.Name
+			if linkItems {
+				noteName := item.NoteName
+				if noteName == "" {
+					noteName = item.Name
+				}
+				name = linkTo(noteName)
+			}
+			md.WriteString(fmt.Sprintf("| %s | %s |\n",
+				strings.ReplaceAll(name, "|", "\\|"),
+				strings.ReplaceAll(linkToReference(item.BaseItem), "|", "\\|")))
+		}
+		md.WriteString("\n")
+	}
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", generic.Source))
+	if generic.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", generic.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
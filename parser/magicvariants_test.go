@@ -0,0 +1,312 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testWeaponVariant() MagicVariant {
+	return MagicVariant{
+		Name:     "+1 Weapon",
+		Type:     "GV|DMG",
+		Requires: []map[string]interface{}{{"weapon": true}},
+		Excludes: map[string]interface{}{"name": []interface{}{"Net"}},
+		Inherits: map[string]interface{}{
+			"namePrefix":  "+1 ",
+			"source":      "DMG",
+			"page":        float64(213),
+			"rarity":      "uncommon",
+			"bonusWeapon": "+1",
+			"entries": []interface{}{
+				"You have a {=bonusWeapon} bonus to attack and damage rolls made with this magic {=baseName/l}.",
+			},
+		},
+		Entries: []interface{}{"You have a bonus to attack and damage rolls made with this magic weapon."},
+	}
+}
+
+func TestVariantAppliesTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		variant  MagicVariant
+		baseItem map[string]interface{}
+		expected bool
+	}{
+		{
+			name:     "Matching requirement",
+			variant:  testWeaponVariant(),
+			baseItem: map[string]interface{}{"name": "Longsword", "weapon": true},
+			expected: true,
+		},
+		{
+			name:     "Excluded by name",
+			variant:  testWeaponVariant(),
+			baseItem: map[string]interface{}{"name": "Net", "weapon": true},
+			expected: false,
+		},
+		{
+			name:     "Missing requirement",
+			variant:  testWeaponVariant(),
+			baseItem: map[string]interface{}{"name": "Chain Mail", "armor": true},
+			expected: false,
+		},
+		{
+			name: "Type without source matches type with source",
+			variant: MagicVariant{
+				Requires: []map[string]interface{}{{"type": "HA"}, {"type": "MA"}},
+			},
+			baseItem: map[string]interface{}{"name": "Chain Mail", "type": "HA|XPHB"},
+			expected: true,
+		},
+		{
+			name: "Excluded by property",
+			variant: MagicVariant{
+				Requires: []map[string]interface{}{{"sword": true}},
+				Excludes: map[string]interface{}{"property": "2H"},
+			},
+			baseItem: map[string]interface{}{"name": "Greatsword", "sword": true, "property": []interface{}{"H", "2H"}},
+			expected: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := variantAppliesTo(tt.variant, tt.baseItem); result != tt.expected {
+				t.Errorf("variantAppliesTo() = %v, want %v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestSpecificVariantItem(t *testing.T) {
+	baseItem := map[string]interface{}{
+		"name":   "Longsword",
+		"source": "PHB",
+		"type":   "M",
+		"weight": float64(3),
+		"weapon": true,
+	}
+
+	item := specificVariantItem(testWeaponVariant(), baseItem)
+
+	result, err := itemToMarkdown(item, nil)
+	if err != nil {
+		t.Fatalf("itemToMarkdown() error = %v", err)
+	}
+
+	expected := "# +1 Longsword\n\n" +
+		"*Melee weapon (longsword), uncommon*\n\n" +
+		"**Weight:** 3.0 lb.\n\n" +
+		"**Bonus:** +1 to attack and damage rolls\n\n" +
+		"**Variant of:** [[+1 Weapon]]\n\n" +
+		"**Base Item:** [[Longsword]]\n\n" +
+		"You have a +1 bonus to attack and damage rolls made with this magic longsword.\n\n" +
+		"**Source:** DMG, page 213\n"
+	if result != expected {
+		t.Errorf("itemToMarkdown() = %q, want %q", result, expected)
+	}
+}
+
+func TestSpecificVariantItem_ValueAndWeight(t *testing.T) {
+	variant := MagicVariant{
+		Name:     "Mithral Armor",
+		Requires: []map[string]interface{}{{"type": "MA"}},
+		Inherits: map[string]interface{}{"nameSuffix": " of Mithral", "valueMult": float64(2), "weightMult": float64(0.5)},
+	}
+	baseItem := map[string]interface{}{"name": "Breastplate", "source": "PHB", "type": "MA", "value": float64(40000), "weight": float64(20)}
+
+	item := specificVariantItem(variant, baseItem)
+	if item.Value != float64(80000) {
+		t.Errorf("specificVariantItem().Value = %v, want 80000", item.Value)
+	}
+	if item.Weight != 10 {
+		t.Errorf("specificVariantItem().Weight = %v, want 10", item.Weight)
+	}
+
+	// Without a multiplier the base item's value doesn't carry over
+	item = specificVariantItem(testWeaponVariant(), map[string]interface{}{"name": "Dagger", "source": "PHB", "value": float64(200)})
+	if item.Value != nil {
+		t.Errorf("specificVariantItem().Value = %v, want nil", item.Value)
+	}
+}
+
+func TestMagicVariantToMarkdown(t *testing.T) {
+	variant := testWeaponVariant()
+	items := []Item{
+		specificVariantItem(variant, map[string]interface{}{"name": "Dagger", "source": "PHB", "weapon": true}),
+	}
+
+	tests := []struct {
+		name      string
+		linkItems bool
+		expected  string
+	}{
+		{
+			name:      "Linked items",
+			linkItems: true,
+			expected:  "| [[+1 Dagger]] | [[Dagger]] |",
+		},
+		{
+			name:      "Grouped items",
+			linkItems: false,
+			expected:  "| +1 Dagger | [[Dagger]] |",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := magicVariantToMarkdown(variant, items, tt.linkItems, nil)
+			if err != nil {
+				t.Fatalf("magicVariantToMarkdown() error = %v", err)
+			}
+			if !strings.HasPrefix(result, "# +1 Weapon\n\n*Generic variant, uncommon*\n\n") {
+				t.Errorf("magicVariantToMarkdown() has wrong header, got %s", result)
+			}
+			if !strings.Contains(result, tt.expected) {
+				t.Errorf("magicVariantToMarkdown() missing %q, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestParseMagicVariants_WithMockData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "magicvariants-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	variantData, err := json.Marshal(MagicVariantFile{MagicVariant: []MagicVariant{testWeaponVariant()}})
+	if err != nil {
+		t.Fatalf("Failed to marshal magic variant data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "magicvariants.json"), variantData, 0644); err != nil {
+		t.Fatalf("Failed to write magicvariants.json: %v", err)
+	}
+
+	baseData, err := json.Marshal(map[string]interface{}{
+		"baseitem": []interface{}{
+			map[string]interface{}{"name": "Longsword", "source": "PHB", "weapon": true},
+			map[string]interface{}{"name": "Net", "source": "PHB", "weapon": true},
+			map[string]interface{}{"name": "Chain Mail", "source": "PHB", "armor": true},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Failed to marshal base item data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "items-base.json"), baseData, 0644); err != nil {
+		t.Fatalf("Failed to write items-base.json: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		grouped       bool
+		expectedFiles []string
+	}{
+		{name: "Specific items", grouped: false, expectedFiles: []string{"+1 Longsword.md", "+1 Weapon.md"}},
+		{name: "Grouped", grouped: true, expectedFiles: []string{"+1 Weapon.md"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outDir := filepath.Join(tempDir, "out-"+strings.ReplaceAll(strings.ToLower(tt.name), " ", "-"))
+			if err := parseMagicVariants(context.Background(), dataDir, outDir, "", nil, tt.grouped, false); err != nil {
+				t.Fatalf("parseMagicVariants() error = %v", err)
+			}
+
+			files, err := os.ReadDir(filepath.Join(outDir, "items"))
+			if err != nil {
+				t.Fatalf("Failed to read output directory: %v", err)
+			}
+			var names []string
+			for _, file := range files {
+				names = append(names, file.Name())
+			}
+			if strings.Join(names, ",") != strings.Join(tt.expectedFiles, ",") {
+				t.Errorf("parseMagicVariants() wrote %v, want %v", names, tt.expectedFiles)
+			}
+		})
+	}
+}
+
+func TestParseMagicVariants_WithReprints(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "magicvariants-reprints-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	original := testWeaponVariant()
+	original.ReprintedAs = []interface{}{"+1 Weapon|XDMG"}
+	reprint := testWeaponVariant()
+	reprint.Inherits = map[string]interface{}{"namePrefix": "+1 ", "source": "XDMG", "rarity": "uncommon"}
+
+	files := map[string]interface{}{
+		"magicvariants.json": MagicVariantFile{MagicVariant: []MagicVariant{original, reprint}},
+		"items-base.json": map[string]interface{}{
+			"baseitem": []interface{}{
+				map[string]interface{}{"name": "Longsword", "source": "PHB", "weapon": true, "reprintedAs": []interface{}{"Longsword|XPHB"}},
+				map[string]interface{}{"name": "Longsword", "source": "XPHB", "weapon": true},
+			},
+		},
+	}
+	for name, content := range files {
+		data, err := json.Marshal(content)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dataDir, name), data, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	if err := parseMagicVariants(context.Background(), dataDir, outDir, EditionBoth, nil, false, false); err != nil {
+		t.Fatalf("parseMagicVariants() error = %v", err)
+	}
+
+	// Every combination of the variant and base item printings gets its own note
+	expectedFiles := []string{
+		"+1 Longsword (DMG).md",
+		"+1 Longsword (DMG, PHB).md",
+		"+1 Longsword (PHB).md",
+		"+1 Longsword.md",
+		"+1 Weapon (DMG).md",
+		"+1 Weapon.md",
+	}
+	entries, err := os.ReadDir(filepath.Join(outDir, "items"))
+	if err != nil {
+		t.Fatalf("Failed to read output directory: %v", err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	if strings.Join(names, ",") != strings.Join(expectedFiles, ",") {
+		t.Errorf("parseMagicVariants() wrote %v, want %v", names, expectedFiles)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outDir, "items", "+1 Weapon.md"))
+	if err != nil {
+		t.Fatalf("Failed to read +1 Weapon.md: %v", err)
+	}
+	for _, expected := range []string{"| [[+1 Longsword (PHB)]] |", "| [[+1 Longsword]] |"} {
+		if !strings.Contains(string(content), expected) {
+			t.Errorf("+1 Weapon.md missing %q, got %s", expected, content)
+		}
+	}
+}
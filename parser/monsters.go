@@ -6,9 +6,20 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
+	"sort"
 	"strings"
 )
 
+var (
+	// monsterSensePattern matches the senses with notes of their own, e.g. "darkvision" in "darkvision 60 ft."
+	monsterSensePattern = regexp.MustCompile(`(?i)\b(blindsight|darkvision|tremorsense|truesight)\b`)
+
+	// monsterLanguagePattern matches the runs of capitalized words in a language list that may name languages,
+	// e.g. "Common" and "Thieves' Cant" in "Common, Thieves' Cant, understands Draconic but can't speak"
+	monsterLanguagePattern = regexp.MustCompile(`\b[A-Z][a-z']*(?: [A-Z][a-z']*)*`)
+)
+
 // MonsterIndex represents the structure of the bestiary/index.json file
 type MonsterIndex map[string]string
 
@@ -45,6 +56,7 @@ type Monster struct {
 	Reaction    []MonsterTrait    `json:"reaction,omitempty"`
 	Environment []string          `json:"environment,omitempty"`
 	Entries     []interface{}     `json:"entries,omitempty"`
+	ReprintedAs []interface{}     `json:"reprintedAs,omitempty"` // Can be "Goblin|XMM" or {"uid": "Goblin|XMM", "tag": "creature"}
 	// Additional fields can be added as needed
 }
 
@@ -55,7 +67,8 @@ type MonsterTrait struct {
 }
 
 // parseMonsters parses the monster data from the specified directory and writes it to the output directory.
-func parseMonsters(ctx context.Context, dataDirectory, outDirectory string) error {
+// The edition decides which printings of reprinted monsters are written, and the filter which sources are.
+func parseMonsters(ctx context.Context, dataDirectory, outDirectory, edition string, filter *sourceFilter) error {
 	var (
 		bestiaryPath = filepath.Join(dataDirectory, "bestiary")
 		indexPath    = filepath.Join(bestiaryPath, "index.json")
@@ -78,65 +91,68 @@ func parseMonsters(ctx context.Context, dataDirectory, outDirectory string) erro
 		return fmt.Errorf("failed to parse index file: %w", err)
 	}
 
-	// Process each monster file
-	for source, filename := range index {
-		if err := processMonsterFile(ctx, bestiaryPath, outDir, source, filename); err != nil {
-			return fmt.Errorf("failed to process monster file %s: %w", filename, err)
-		}
-	}
-
-	return nil
-}
-
-// processMonsterFile processes a single monster file and generates Markdown for each monster
-func processMonsterFile(ctx context.Context, bestiaryPath, outDir, source, filename string) error {
-	// Read and parse the monster file
-	filePath := filepath.Join(bestiaryPath, filename)
-	fileData, err := os.ReadFile(filePath)
+	// Only the languages in languages.json have notes to link to
+	languages, err := readLanguageNames(dataDirectory)
 	if err != nil {
-		return fmt.Errorf("failed to read monster file: %w", err)
+		return err
 	}
 
-	var monsterFile MonsterFile
-	if err := json.Unmarshal(fileData, &monsterFile); err != nil {
-		return fmt.Errorf("failed to parse monster file: %w", err)
+	// Read every monster file before writing any notes, since the reprints of monsters in one book are in another
+	reprints := newReprintFilter(edition, filter)
+	var allMonsters []Monster
+	for _, filename := range index {
+		monsters, err := readMonsterFile(bestiaryPath, filename)
+		if err != nil {
+			return fmt.Errorf("failed to process monster file %s: %w", filename, err)
+		}
+		for _, monster := range monsters {
+			reprints.add(monster.Source, monster.ReprintedAs)
+		}
+		allMonsters = append(allMonsters, monsters...)
 	}
 
 	// Process each monster
-	for _, monster := range monsterFile.Monster {
-		mdContent, err := monsterToMarkdown(monster)
+	for _, monster := range allMonsters {
+		if !filter.keep(monster.Source) || !reprints.keep(monster.Name, monster.Source, monster.ReprintedAs) {
+			continue
+		}
+		monster.ReprintedAs = reprints.links(monster.ReprintedAs)
+
+		mdContent, err := monsterToMarkdown(monster, languages)
 		if err != nil {
 			return fmt.Errorf("failed to convert monster to markdown: %w", err)
 		}
 
-		// Create a filename for the monster
-		safeMonsterName := strings.ReplaceAll(monster.Name, "/", "-")
-		safeMonsterName = strings.ReplaceAll(safeMonsterName, "\\", "-")
-		safeMonsterName = strings.ReplaceAll(safeMonsterName, ":", "-")
-		safeMonsterName = strings.ReplaceAll(safeMonsterName, "*", "-")
-		safeMonsterName = strings.ReplaceAll(safeMonsterName, "?", "-")
-		safeMonsterName = strings.ReplaceAll(safeMonsterName, "\"", "-")
-		safeMonsterName = strings.ReplaceAll(safeMonsterName, "<", "-")
-		safeMonsterName = strings.ReplaceAll(safeMonsterName, ">", "-")
-		safeMonsterName = strings.ReplaceAll(safeMonsterName, "|", "-")
-
-		mdFilePath := filepath.Join(outDir, safeMonsterName+".md")
-
-		// Write the markdown file
-		if err := os.WriteFile(mdFilePath, []byte(mdContent), 0644); err != nil {
-			return fmt.Errorf("failed to write markdown file: %w", err)
+		if err := writeNote(outDir, reprints.noteName(monster.Name, monster.Source, monster.ReprintedAs), mdContent); err != nil {
+			return err
 		}
 	}
 
 	return nil
 }
 
+// readMonsterFile reads and parses a single monster file
+func readMonsterFile(bestiaryPath, filename string) ([]Monster, error) {
+	fileData, err := os.ReadFile(filepath.Join(bestiaryPath, filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read monster file: %w", err)
+	}
+
+	var monsterFile MonsterFile
+	if err := json.Unmarshal(fileData, &monsterFile); err != nil {
+		return nil, fmt.Errorf("failed to parse monster file: %w", err)
+	}
+
+	return monsterFile.Monster, nil
+}
+
 // monsterToMarkdown converts a monster to Markdown format
-func monsterToMarkdown(monster Monster) (string, error) {
+func monsterToMarkdown(monster Monster, languages map[string]bool) (string, error) {
 	var md strings.Builder
 
 	// Title
 	md.WriteString(fmt.Sprintf("# %s\n\n", monster.Name))
+	writeReprintBanner(&md, "creature", monster.ReprintedAs)
 
 	// Basic info
 	var typeStr string
@@ -271,8 +287,11 @@ func monsterToMarkdown(monster Monster) (string, error) {
 	if len(monster.Save) > 0 {
 		md.WriteString("**Saving Throws** ")
 		saves := make([]string, 0, len(monster.Save))
-		for ability, bonus := range monster.Save {
-			saves = append(saves, fmt.Sprintf("%s %s", strings.ToUpper(ability), bonus))
+		// Use the stat block order rather than map order so the output is stable
+		for _, ability := range []string{"str", "dex", "con", "int", "wis", "cha"} {
+			if bonus, ok := monster.Save[ability]; ok {
+				saves = append(saves, fmt.Sprintf("%s %s", strings.ToUpper(ability), bonus))
+			}
 		}
 		md.WriteString(strings.Join(saves, ", "))
 		md.WriteString("\n\n")
@@ -285,8 +304,9 @@ func monsterToMarkdown(monster Monster) (string, error) {
 			md.WriteString("**Skills** ")
 			skills := make([]string, 0, len(skill))
 			for skillName, bonus := range skill {
-				skills = append(skills, fmt.Sprintf("%s %s", skillName, bonus))
+				skills = append(skills, fmt.Sprintf("%s %s", linkTo(titleCase(skillName)), bonus))
 			}
+			sort.Strings(skills)
 			md.WriteString(strings.Join(skills, ", "))
 			md.WriteString("\n\n")
 		}
@@ -296,9 +316,10 @@ func monsterToMarkdown(monster Monster) (string, error) {
 			skills := make([]string, 0, len(skill))
 			for skillName, bonus := range skill {
 				if bonusStr, ok := bonus.(string); ok {
-					skills = append(skills, fmt.Sprintf("%s %s", skillName, bonusStr))
+					skills = append(skills, fmt.Sprintf("%s %s", linkTo(titleCase(skillName)), bonusStr))
 				}
 			}
+			sort.Strings(skills)
 			md.WriteString(strings.Join(skills, ", "))
 			md.WriteString("\n\n")
 		}
@@ -308,12 +329,12 @@ func monsterToMarkdown(monster Monster) (string, error) {
 	md.WriteString("**Senses** ")
 	switch senses := monster.Senses.(type) {
 	case string:
-		md.WriteString(senses)
+		md.WriteString(linkMonsterSenses(senses))
 	case []interface{}:
 		senseStrs := make([]string, 0, len(senses))
 		for _, sense := range senses {
 			if senseStr, ok := sense.(string); ok {
-				senseStrs = append(senseStrs, senseStr)
+				senseStrs = append(senseStrs, linkMonsterSenses(senseStr))
 			}
 		}
 		md.WriteString(strings.Join(senseStrs, ", "))
@@ -341,7 +362,7 @@ func monsterToMarkdown(monster Monster) (string, error) {
 
 	if languagesContent != "" {
 		md.WriteString("**Languages** ")
-		md.WriteString(languagesContent)
+		md.WriteString(linkMonsterLanguages(languagesContent, languages))
 		md.WriteString("\n\n")
 	}
 
@@ -510,3 +531,44 @@ func processRechargeTagsDirectly(text string) string {
 
 	return text
 }
+
+// linkMonsterSenses links the senses in a monster's senses, e.g. "darkvision 60 ft., passive Perception 12" ->
+// "[[darkvision]] 60 ft., passive [[Perception]] 12". Senses that are already tagged keep their tags' links.
+func linkMonsterSenses(senses string) string {
+	if strings.Contains(senses, "{@") {
+		return processSpecialFormatting(senses)
+	}
+	senses = monsterSensePattern.ReplaceAllStringFunc(senses, linkTo)
+	return strings.ReplaceAll(senses, "passive Perception", "passive "+linkTo("Perception"))
+}
+
+// linkMonsterLanguages links the known languages in a monster's languages, e.g. "Common, understands Draconic but
+// can't speak" -> "[[Common]], understands [[Draconic]] but can't speak". The known languages are keyed by lower
+// case name, and other capitalized words such as "All" or "Telepathy" are left unlinked. Languages that are already
+// tagged keep their tags' links.
+func linkMonsterLanguages(languages string, known map[string]bool) string {
+	if strings.Contains(languages, "{@") {
+		return processSpecialFormatting(languages)
+	}
+	return monsterLanguagePattern.ReplaceAllStringFunc(languages, func(run string) string {
+		words := strings.Split(run, " ")
+		var linked []string
+		for len(words) > 0 {
+			// Link the longest known language at the start of the run, e.g. "Deep Speech" rather than "Deep"
+			n := len(words)
+			for ; n > 0; n-- {
+				if known[strings.ToLower(strings.Join(words[:n], " "))] {
+					break
+				}
+			}
+			if n == 0 {
+				linked = append(linked, words[0])
+				words = words[1:]
+				continue
+			}
+			linked = append(linked, linkTo(strings.Join(words[:n], " ")))
+			words = words[n:]
+		}
+		return strings.Join(linked, " ")
+	})
+}
@@ -131,7 +131,7 @@ func TestMonsterToMarkdown(t *testing.T) {
 		},
 	}
 
-	md, err := monsterToMarkdown(monster)
+	md, err := monsterToMarkdown(monster, map[string]bool{"common": true, "elvish": true})
 	if err != nil {
 		t.Fatalf("monsterToMarkdown() error = %v", err)
 	}
@@ -146,9 +146,9 @@ func TestMonsterToMarkdown(t *testing.T) {
 		"|STR|DEX|CON|INT|WIS|CHA|",
 		"|16 (+3)|14 (+2)|12 (+1)|10 (+0)|8 (-1)|6 (-2)|",
 		"**Saving Throws** STR +5, DEX +4",
-		"**Skills** Perception +2, Stealth +4",
-		"**Senses** darkvision 60 ft., passive Perception 12",
-		"**Languages** Common, Elvish",
+		"**Skills** [[Perception]] +2, [[Stealth]] +4",
+		"**Senses** [[darkvision]] 60 ft., passive [[Perception]] 12",
+		"**Languages** [[Common]], [[Elvish]]",
 		"**Challenge** 2",
 		"## Traits",
 		"***Keen Senses.*** The monster has advantage on Wisdom (Perception) checks that rely on sight.",
@@ -237,8 +237,17 @@ func TestParseMonsters_WithMockData(t *testing.T) {
 		t.Fatalf("Failed to write monster file: %v", err)
 	}
 
+	// Create a mock language file, so the monster's languages are linked
+	languageBytes, err := json.Marshal(LanguageFile{Language: []Language{{Name: "Common", Source: "PHB"}}})
+	if err != nil {
+		t.Fatalf("Failed to marshal language data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "languages.json"), languageBytes, 0644); err != nil {
+		t.Fatalf("Failed to write language file: %v", err)
+	}
+
 	// Parse the monsters
-	if err := parseMonsters(context.Background(), dataDir, outDir); err != nil {
+	if err := parseMonsters(context.Background(), dataDir, outDir, "", nil); err != nil {
 		t.Fatalf("parseMonsters() error = %v", err)
 	}
 
@@ -263,8 +272,8 @@ func TestParseMonsters_WithMockData(t *testing.T) {
 		"**Speed** 30 ft.",
 		"|STR|DEX|CON|INT|WIS|CHA|",
 		"|10 (+0)|10 (+0)|10 (+0)|10 (+0)|10 (+0)|10 (+0)|",
-		"**Senses** passive Perception 10",
-		"**Languages** Common",
+		"**Senses** passive [[Perception]] 10",
+		"**Languages** [[Common]]",
 		"**Challenge** 1/4",
 		"## Actions",
 		"***Shortsword.*** Melee Weapon Attack: +2 to hit, reach 5 ft., one target. Hit: 4 (1d6 + 1) piercing damage.",
@@ -410,7 +419,7 @@ func TestMonsterToMarkdown_EdgeCases(t *testing.T) {
 				CR:        "1",
 			},
 			expected: []string{
-				"**Senses** darkvision 60 ft., tremorsense 30 ft., passive Perception 10",
+				"**Senses** [[darkvision]] 60 ft., [[tremorsense]] 30 ft., passive [[Perception]] 10",
 			},
 		},
 		{
@@ -441,7 +450,7 @@ func TestMonsterToMarkdown_EdgeCases(t *testing.T) {
 			},
 			expected: []string{
 				"*Medium monstrosity, unaligned*",
-				"**Senses** darkvision 60 ft.",
+				"**Senses** [[darkvision]] 60 ft.",
 				"**Challenge** 3",
 			},
 			notExpected: []string{
@@ -452,7 +461,7 @@ func TestMonsterToMarkdown_EdgeCases(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			md, err := monsterToMarkdown(tt.monster)
+			md, err := monsterToMarkdown(tt.monster, nil)
 			if err != nil {
 				t.Fatalf("monsterToMarkdown() error = %v", err)
 			}
@@ -471,3 +480,46 @@ func TestMonsterToMarkdown_EdgeCases(t *testing.T) {
 		})
 	}
 }
+
+func TestLinkMonsterSenses(t *testing.T) {
+	tests := []struct {
+		senses   string
+		expected string
+	}{
+		{"darkvision 60 ft.", "[[darkvision]] 60 ft."},
+		{"blindsight 30 ft. (blind beyond this radius)", "[[blindsight]] 30 ft. (blind beyond this radius)"},
+		{"passive Perception 14", "passive [[Perception]] 14"},
+		{"{@sense truesight|XPHB} 120 ft.", "[[truesight]] 120 ft."},
+	}
+
+	for _, tt := range tests {
+		if result := linkMonsterSenses(tt.senses); result != tt.expected {
+			t.Errorf("linkMonsterSenses(%q) = %q, want %q", tt.senses, result, tt.expected)
+		}
+	}
+}
+
+func TestLinkMonsterLanguages(t *testing.T) {
+	tests := []struct {
+		languages string
+		expected  string
+	}{
+		{"Common, Elvish", "[[Common]], [[Elvish]]"},
+		{"Deep Speech, telepathy 120 ft.", "[[Deep Speech]], telepathy 120 ft."},
+		{"Thieves' Cant", "[[Thieves' Cant]]"},
+		{"understands Common and Auran but can't speak", "understands [[Common]] and [[Auran]] but can't speak"},
+		{"any one language (usually Common)", "any one language (usually [[Common]])"},
+		{"{@language Abyssal}", "[[Abyssal]]"},
+		{"understands all languages it knew in life but can't speak", "understands all languages it knew in life but can't speak"},
+		{"All, Telepathy 120 ft.", "All, Telepathy 120 ft."},
+		{"Any four languages", "Any four languages"},
+		{"Understands Common But Can't Speak", "Understands [[Common]] But Can't Speak"},
+	}
+
+	known := map[string]bool{"common": true, "elvish": true, "deep speech": true, "thieves' cant": true, "auran": true}
+	for _, tt := range tests {
+		if result := linkMonsterLanguages(tt.languages, known); result != tt.expected {
+			t.Errorf("linkMonsterLanguages(%q) = %q, want %q", tt.languages, result, tt.expected)
+		}
+	}
+}
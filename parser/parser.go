@@ -2,11 +2,49 @@ package parser
 
 import (
 	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
 )
 
 type Config struct {
 	DataDirectory string
 	OutDirectory  string
+
+	// ImageDirectory is the path of the 5etools img directory within the Obsidian vault, e.g. "img". Item notes
+	// embed their images from it, and images are left out when it is empty.
+	ImageDirectory string
+
+	// Edition picks between the 2014 and 2024 printings of reprinted spells, monsters and items: Edition2014 keeps
+	// the originals, Edition2024 keeps the reprints, and EditionBoth (the default) keeps both and links each
+	// original to its reprint.
+	Edition string
+
+	// IncludeSources and ExcludeSources restrict the spells, monsters and items that are written to those from, or
+	// not from, the given sources, e.g. "PHB" or "XPHB". A trailing "*" matches every source with that prefix, so
+	// "UA*" matches all of Unearthed Arcana. Exclusions win over inclusions, and nothing is restricted when empty.
+	IncludeSources []string
+	ExcludeSources []string
+
+	// IncludeGroups and ExcludeGroups restrict them by publication group, as listed in books.json and
+	// adventures.json: GroupCore, GroupSupplements, GroupAdventures, GroupPartnered, GroupPlaytest or GroupHomebrew.
+	// They combine with the source lists, so including "core" and "XGE" writes the core books and Xanathar's.
+	IncludeGroups []string
+	ExcludeGroups []string
+
+	// BookSectionDepth controls how books and adventures are split into notes. 0 writes one note per chapter,
+	// 1 also splits out each section of a chapter, and so on.
+	BookSectionDepth int
+
+	// GroupItemVariants writes each generic magic variant, e.g. "+1 Weapon", as a single note with a table of the
+	// base items it applies to, instead of also writing a note for every specific item such as "+1 Longsword".
+	GroupItemVariants bool
+
+	// EstimateItemValues gives magic items without a value of their own the price range the Dungeon Master's Guide
+	// suggests for their rarity.
+	EstimateItemValues bool
 }
 
 type Parser struct {
@@ -21,14 +59,211 @@ func New(config Config) *Parser {
 
 // ParseSpells parses the spell data from the specified directory and writes it to the output directory.
 func (p Parser) ParseSpells(ctx context.Context) error {
-	return parseSpells(ctx, p.DataDirectory, p.OutDirectory)
+	filter, err := p.sourceFilter()
+	if err != nil {
+		return err
+	}
+	return parseSpells(ctx, p.DataDirectory, p.OutDirectory, p.Edition, filter)
 }
 
 func (p Parser) ParseMonsters(ctx context.Context) error {
-	return parseMonsters(ctx, p.DataDirectory, p.OutDirectory)
+	filter, err := p.sourceFilter()
+	if err != nil {
+		return err
+	}
+	return parseMonsters(ctx, p.DataDirectory, p.OutDirectory, p.Edition, filter)
 }
 
-// ParseItems parses the item data from the specified directory and writes it to the output directory.
+// ParseItems parses the item data from the specified directory and writes it to the output directory,
+// including the specific items generated from the generic magic variants.
 func (p Parser) ParseItems(ctx context.Context) error {
-	return parseItems(ctx, p.DataDirectory, p.OutDirectory)
+	filter, err := p.sourceFilter()
+	if err != nil {
+		return err
+	}
+	if err := parseItems(ctx, p.DataDirectory, p.OutDirectory, p.ImageDirectory, p.Edition, filter, p.EstimateItemValues); err != nil {
+		return err
+	}
+	return parseMagicVariants(ctx, p.DataDirectory, p.OutDirectory, p.Edition, filter, p.GroupItemVariants, p.EstimateItemValues)
+}
+
+// ParseFeats parses the feat data from the specified directory and writes it to the output directory.
+func (p Parser) ParseFeats(ctx context.Context) error {
+	return parseFeats(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseOptionalFeatures parses the optional feature data (Eldritch Invocations, Fighting Styles, Metamagic, etc.)
+// from the specified directory and writes it to the output directory.
+func (p Parser) ParseOptionalFeatures(ctx context.Context) error {
+	return parseOptionalFeatures(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseConditions parses the condition, disease and status data from the specified directory and writes it to
+// the output directory.
+func (p Parser) ParseConditions(ctx context.Context) error {
+	return parseConditions(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseActions parses the action data from the specified directory and writes it to the output directory.
+func (p Parser) ParseActions(ctx context.Context) error {
+	return parseActions(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseSenses parses the sense data from the specified directory and writes it to the output directory.
+func (p Parser) ParseSenses(ctx context.Context) error {
+	return parseSenses(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseSkills parses the skill data from the specified directory and writes it to the output directory.
+func (p Parser) ParseSkills(ctx context.Context) error {
+	return parseSkills(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseLanguages parses the language data from the specified directory and writes it to the output directory.
+func (p Parser) ParseLanguages(ctx context.Context) error {
+	return parseLanguages(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseVariantRules parses the core, optional and variant rule data from the specified directory and writes it
+// to the output directory.
+func (p Parser) ParseVariantRules(ctx context.Context) error {
+	return parseVariantRules(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseDeities parses the deity data from the specified directory and writes it to the output directory,
+// along with an index note for each pantheon.
+func (p Parser) ParseDeities(ctx context.Context) error {
+	return parseDeities(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseTrapsHazards parses the trap and hazard data from the specified directory and writes it to the output directory.
+func (p Parser) ParseTrapsHazards(ctx context.Context) error {
+	return parseTrapsHazards(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseVehicles parses the vehicle data (ships, infernal war machines, spelljammers) from the specified directory
+// and writes it to the output directory.
+func (p Parser) ParseVehicles(ctx context.Context) error {
+	return parseVehicles(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseObjects parses the object data (siege weapons and other objects) from the specified directory and writes it
+// to the output directory.
+func (p Parser) ParseObjects(ctx context.Context) error {
+	return parseObjects(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseTables parses the standalone roll tables and table groups from the specified directory and writes them to
+// the output directory.
+func (p Parser) ParseTables(ctx context.Context) error {
+	return parseTables(ctx, p.DataDirectory, p.OutDirectory)
+}
+
+// ParseBooks parses the full text of the sourcebooks from the specified directory and writes it to the output
+// directory, split into notes per chapter or section.
+func (p Parser) ParseBooks(ctx context.Context) error {
+	return parseBooks(ctx, p.DataDirectory, p.OutDirectory, p.BookSectionDepth)
+}
+
+// ParseAdventures parses the full text of the adventures from the specified directory and writes it to the output
+// directory, split into notes per chapter or section.
+func (p Parser) ParseAdventures(ctx context.Context) error {
+	return parseAdventures(ctx, p.DataDirectory, p.OutDirectory, p.BookSectionDepth)
+}
+
+// sourceFilter creates the filter for the configured sources and publication groups
+func (p Parser) sourceFilter() (*sourceFilter, error) {
+	filter, err := newSourceFilter(p.DataDirectory, p.IncludeSources, p.ExcludeSources, p.IncludeGroups, p.ExcludeGroups)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create source filter: %w", err)
+	}
+	return filter, nil
+}
+
+// safeFileName replaces characters that are not allowed in file names with dashes
+func safeFileName(name string) string {
+	replacer := strings.NewReplacer(
+		"/", "-",
+		"\\", "-",
+		":", "-",
+		"*", "-",
+		"?", "-",
+		"\"", "-",
+		"<", "-",
+		">", "-",
+		"|", "-",
+	)
+	return replacer.Replace(name)
+}
+
+// writeNote writes Markdown content to a note named after the given name in the output directory
+func writeNote(outDir, name, content string) error {
+	mdFilePath := filepath.Join(outDir, safeFileName(name)+".md")
+	if err := os.WriteFile(mdFilePath, []byte(content), 0644); err != nil {
+		return fmt.Errorf("failed to write markdown file: %w", err)
+	}
+	return nil
+}
+
+// parseReference splits a 5etools reference such as "misty step|XPHB" or "eldritch blast#c"
+// into its name and source. The source is empty when the reference doesn't specify one.
+func parseReference(reference string) (string, string) {
+	var source string
+	if i := strings.Index(reference, "#"); i != -1 {
+		reference = reference[:i]
+	}
+	if i := strings.Index(reference, "|"); i != -1 {
+		source = strings.ToUpper(reference[i+1:])
+		reference = reference[:i]
+	}
+	return strings.TrimSpace(reference), strings.TrimSpace(source)
+}
+
+// linkTo returns an Obsidian wiki link to the note with the given name
+func linkTo(name string) string {
+	safeName := safeFileName(name)
+	if safeName != name {
+		return fmt.Sprintf("[[%s|%s]]", safeName, name)
+	}
+	return fmt.Sprintf("[[%s]]", name)
+}
+
+// linkToReference returns an Obsidian wiki link for a 5etools reference, using title case for the display name
+func linkToReference(reference string) string {
+	name, _ := parseReference(reference)
+	return linkTo(titleCase(name))
+}
+
+// titleCase capitalizes each word in a name, keeping short connecting words in lower case
+func titleCase(name string) string {
+	minorWords := map[string]bool{
+		"a": true, "an": true, "and": true, "as": true, "at": true, "by": true, "for": true,
+		"from": true, "in": true, "into": true, "of": true, "on": true, "or": true, "the": true,
+		"to": true, "with": true,
+	}
+
+	words := strings.Fields(name)
+	for i, word := range words {
+		if i > 0 && minorWords[strings.ToLower(word)] {
+			words[i] = strings.ToLower(word)
+			continue
+		}
+		words[i] = capitalizeParts(word)
+	}
+	return strings.Join(words, " ")
+}
+
+// capitalizeParts capitalizes the first letter of a word and of each part joined by a dash or slash,
+// e.g. "half-elf" -> "Half-Elf"
+func capitalizeParts(word string) string {
+	var result strings.Builder
+	capitalize := true
+	for _, r := range word {
+		if capitalize {
+			r = unicode.ToUpper(r)
+		}
+		capitalize = r == '-' || r == '/'
+		result.WriteRune(r)
+	}
+	return result.String()
 }
@@ -2,3 +2,66 @@ package parser
 
 // This file will contain tests for the generic parser functionality.
 // Tests for spell-specific functionality have been moved to spells_test.go.
+
+import (
+	"testing"
+)
+
+func TestSafeFileName(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"Fireball", "Fireball"},
+		{"Tasha's Hideous Laughter", "Tasha's Hideous Laughter"},
+		{"Potion of Healing (Greater)", "Potion of Healing (Greater)"},
+		{"Spell: Part 1/2", "Spell- Part 1-2"},
+		{"What?*<>|\"\\", "What-------"},
+	}
+
+	for _, test := range tests {
+		result := safeFileName(test.name)
+		if result != test.expected {
+			t.Errorf("safeFileName(%q) = %q; want %q", test.name, result, test.expected)
+		}
+	}
+}
+
+func TestParseReference(t *testing.T) {
+	tests := []struct {
+		reference      string
+		expectedName   string
+		expectedSource string
+	}{
+		{"misty step", "misty step", ""},
+		{"misty step|xphb", "misty step", "XPHB"},
+		{"eldritch blast#c", "eldritch blast", ""},
+		{"eldritch blast|phb#c", "eldritch blast", "PHB"},
+	}
+
+	for _, test := range tests {
+		name, source := parseReference(test.reference)
+		if name != test.expectedName || source != test.expectedSource {
+			t.Errorf("parseReference(%q) = (%q, %q); want (%q, %q)", test.reference, name, source, test.expectedName, test.expectedSource)
+		}
+	}
+}
+
+func TestLinkToReference(t *testing.T) {
+	tests := []struct {
+		reference string
+		expected  string
+	}{
+		{"misty step", "[[Misty Step]]"},
+		{"ring of protection|dmg", "[[Ring of Protection]]"},
+		{"the fiend", "[[The Fiend]]"},
+		{"antipathy/sympathy", "[[Antipathy-Sympathy|Antipathy/Sympathy]]"},
+	}
+
+	for _, test := range tests {
+		result := linkToReference(test.reference)
+		if result != test.expected {
+			t.Errorf("linkToReference(%q) = %q; want %q", test.reference, result, test.expected)
+		}
+	}
+}
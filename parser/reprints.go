@@ -0,0 +1,131 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// The editions that Config.Edition can select
+const (
+	Edition2014 = "2014"
+	Edition2024 = "2024"
+	EditionBoth = "both"
+)
+
+// reprintFilter decides which printings of a reprinted spell, monster or item are written, following the
+// reprintedAs references that older printings have to their replacements
+type reprintFilter struct {
+	edition   string
+	sources   *sourceFilter   // Printings from sources that aren't written don't replace or link to anything
+	reprinted map[string]bool // The lower case "name|source" of every printing that replaces an older one
+}
+
+// newReprintFilter creates a filter for the given edition. An empty or unknown edition keeps both.
+func newReprintFilter(edition string, sources *sourceFilter) *reprintFilter {
+	if edition != Edition2014 && edition != Edition2024 {
+		edition = EditionBoth
+	}
+	return &reprintFilter{edition: edition, sources: sources, reprinted: make(map[string]bool)}
+}
+
+// add records the replacements of an older printing from the given source, so they can be left out when
+// keeping the 2014 edition
+func (f *reprintFilter) add(source string, reprintedAs []interface{}) {
+	if !f.sources.keep(source) {
+		return
+	}
+	for _, reprint := range reprintedAs {
+		name, source := parseReprint(reprint)
+		f.reprinted[strings.ToLower(name+"|"+source)] = true
+	}
+}
+
+// keep reports whether a printing should be written. The 2024 edition leaves out printings that have been
+// replaced, and the 2014 edition leaves out the replacements.
+func (f *reprintFilter) keep(name, source string, reprintedAs []interface{}) bool {
+	switch f.edition {
+	case Edition2024:
+		return len(f.written(reprintedAs)) == 0
+	case Edition2014:
+		return !f.reprinted[strings.ToLower(name+"|"+source)]
+	default:
+		return true
+	}
+}
+
+// linksReprints reports whether older printings link to their replacements, which is only useful when both
+// are written
+func (f *reprintFilter) linksReprints() bool {
+	return f.edition == EditionBoth
+}
+
+// links returns the replacements an older printing should link to: those that are written, and only when both
+// editions are written
+func (f *reprintFilter) links(reprintedAs []interface{}) []interface{} {
+	if !f.linksReprints() {
+		return nil
+	}
+	return f.written(reprintedAs)
+}
+
+// written returns the replacements whose sources are written
+func (f *reprintFilter) written(reprintedAs []interface{}) []interface{} {
+	var written []interface{}
+	for _, reprint := range reprintedAs {
+		if _, source := parseReprint(reprint); f.sources.keep(source) {
+			written = append(written, reprint)
+		}
+	}
+	return written
+}
+
+// noteName returns the note name for a printing. When both editions are written and a replacement has the same
+// name, the older printing is written as e.g. "Fireball (PHB)" so it doesn't overwrite the newer one.
+func (f *reprintFilter) noteName(name, source string, reprintedAs []interface{}) string {
+	if !f.linksReprints() {
+		return name
+	}
+	for _, reprint := range reprintedAs {
+		if reprintName, _ := parseReprint(reprint); strings.EqualFold(reprintName, name) {
+			return fmt.Sprintf("%s (%s)", name, source)
+		}
+	}
+	return name
+}
+
+// parseReprint returns the name and source of a reprintedAs reference, which is either a string such as
+// "Fireball|XPHB" or an object such as {"uid": "Fireball|XPHB", "tag": "spell"}
+func parseReprint(reprint interface{}) (string, string) {
+	switch r := reprint.(type) {
+	case string:
+		return parseReference(r)
+	case map[string]interface{}:
+		if uid, ok := r["uid"].(string); ok {
+			return parseReference(uid)
+		}
+	}
+	return "", ""
+}
+
+// writeReprintBanner writes a callout linking an older printing to its replacements, e.g.
+// "This spell was reprinted as [[Fireball]] (XPHB)."
+func writeReprintBanner(md *strings.Builder, kind string, reprintedAs []interface{}) {
+	var links []string
+	for _, reprint := range reprintedAs {
+		name, source := parseReprint(reprint)
+		if name == "" {
+			continue
+		}
+		link := linkTo(name)
+		if source != "" {
+			link += fmt.Sprintf(" (%s)", source)
+		}
+		links = append(links, link)
+	}
+	if len(links) == 0 {
+		return
+	}
+
+	md.WriteString("> [!note] Reprinted\n")
+	md.WriteString(fmt.Sprintf("> This %s was reprinted as %s.\n\n", kind, joinWithAnd(links)))
+}
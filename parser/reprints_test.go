@@ -0,0 +1,155 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestParseReprint(t *testing.T) {
+	tests := []struct {
+		reprint        interface{}
+		expectedName   string
+		expectedSource string
+	}{
+		{"Fireball|XPHB", "Fireball", "XPHB"},
+		{map[string]interface{}{"uid": "Goblin Warrior|XMM", "tag": "creature"}, "Goblin Warrior", "XMM"},
+		{float64(1), "", ""},
+	}
+
+	for _, tt := range tests {
+		name, source := parseReprint(tt.reprint)
+		if name != tt.expectedName || source != tt.expectedSource {
+			t.Errorf("parseReprint(%v) = %q, %q, want %q, %q", tt.reprint, name, source, tt.expectedName, tt.expectedSource)
+		}
+	}
+}
+
+func TestReprintFilter(t *testing.T) {
+	original := []interface{}{"Fireball|XPHB"}
+
+	tests := []struct {
+		edition          string
+		keepOriginal     bool
+		keepReprint      bool
+		expectedNoteName string
+	}{
+		{Edition2014, true, false, "Fireball"},
+		{Edition2024, false, true, "Fireball"},
+		{EditionBoth, true, true, "Fireball (PHB)"},
+		{"", true, true, "Fireball (PHB)"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.edition, func(t *testing.T) {
+			reprints := newReprintFilter(tt.edition, nil)
+			reprints.add("PHB", original)
+
+			if keep := reprints.keep("Fireball", "PHB", original); keep != tt.keepOriginal {
+				t.Errorf("keep(PHB) = %v, want %v", keep, tt.keepOriginal)
+			}
+			if keep := reprints.keep("Fireball", "XPHB", nil); keep != tt.keepReprint {
+				t.Errorf("keep(XPHB) = %v, want %v", keep, tt.keepReprint)
+			}
+			if name := reprints.noteName("Fireball", "PHB", original); name != tt.expectedNoteName {
+				t.Errorf("noteName() = %q, want %q", name, tt.expectedNoteName)
+			}
+		})
+	}
+}
+
+func TestWriteReprintBanner(t *testing.T) {
+	var md strings.Builder
+	writeReprintBanner(&md, "spell", []interface{}{"Fireball|XPHB"})
+
+	expected := "> [!note] Reprinted\n> This spell was reprinted as [[Fireball]] (XPHB).\n\n"
+	if md.String() != expected {
+		t.Errorf("writeReprintBanner() = %q, want %q", md.String(), expected)
+	}
+
+	md.Reset()
+	writeReprintBanner(&md, "spell", nil)
+	if md.String() != "" {
+		t.Errorf("writeReprintBanner() without reprints = %q, want empty", md.String())
+	}
+}
+
+func TestParseSpells_WithReprints(t *testing.T) {
+	tests := []struct {
+		edition  string
+		expected map[string]bool // Whether each note is written
+	}{
+		{Edition2014, map[string]bool{"Fireball.md": true, "Fireball (PHB).md": false}},
+		{Edition2024, map[string]bool{"Fireball.md": true, "Fireball (PHB).md": false}},
+		{EditionBoth, map[string]bool{"Fireball.md": true, "Fireball (PHB).md": true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.edition, func(t *testing.T) {
+			tempDir, err := os.MkdirTemp("", "reprints-test")
+			if err != nil {
+				t.Fatalf("Failed to create temp dir: %v", err)
+			}
+			defer os.RemoveAll(tempDir)
+
+			dataDir := filepath.Join(tempDir, "data")
+			outDir := filepath.Join(tempDir, "out")
+			if err := os.MkdirAll(filepath.Join(dataDir, "spells"), 0755); err != nil {
+				t.Fatalf("Failed to create spells dir: %v", err)
+			}
+
+			files := map[string]interface{}{
+				"index.json": map[string]string{"PHB": "spells-phb.json", "XPHB": "spells-xphb.json"},
+				"spells-phb.json": SpellFile{Spell: []Spell{
+					{Name: "Fireball", Source: "PHB", Level: 3, School: "V", ReprintedAs: []interface{}{"Fireball|XPHB"}},
+				}},
+				"spells-xphb.json": SpellFile{Spell: []Spell{{Name: "Fireball", Source: "XPHB", Level: 3, School: "V"}}},
+			}
+			for name, content := range files {
+				data, err := json.Marshal(content)
+				if err != nil {
+					t.Fatalf("Failed to marshal %s: %v", name, err)
+				}
+				if err := os.WriteFile(filepath.Join(dataDir, "spells", name), data, 0644); err != nil {
+					t.Fatalf("Failed to write %s: %v", name, err)
+				}
+			}
+
+			if err := parseSpells(context.Background(), dataDir, outDir, tt.edition, nil); err != nil {
+				t.Fatalf("parseSpells() error = %v", err)
+			}
+
+			for name, written := range tt.expected {
+				_, err := os.Stat(filepath.Join(outDir, "spells", name))
+				if exists := err == nil; exists != written {
+					t.Errorf("%s written = %v, want %v", name, exists, written)
+				}
+			}
+
+			content, err := os.ReadFile(filepath.Join(outDir, "spells", "Fireball.md"))
+			if err != nil {
+				t.Fatalf("Failed to read Fireball.md: %v", err)
+			}
+			// The 2014 edition keeps the original, which doesn't link to a reprint that isn't written
+			if strings.Contains(string(content), "[!note] Reprinted") {
+				t.Errorf("Fireball.md has a reprint banner, got %s", content)
+			}
+			if tt.edition == Edition2014 && !strings.Contains(string(content), "**Source:** PHB") {
+				t.Errorf("Fireball.md isn't the PHB printing, got %s", content)
+			}
+
+			if tt.edition == EditionBoth {
+				original, err := os.ReadFile(filepath.Join(outDir, "spells", "Fireball (PHB).md"))
+				if err != nil {
+					t.Fatalf("Failed to read Fireball (PHB).md: %v", err)
+				}
+				if !strings.Contains(string(original), "This spell was reprinted as [[Fireball]] (XPHB).") {
+					t.Errorf("Fireball (PHB).md missing reprint banner, got %s", original)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,352 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ActionFile represents the structure of the actions.json file
+type ActionFile struct {
+	Action []Action `json:"action"`
+}
+
+// Action represents a single action such as Dash or Help
+type Action struct {
+	Name          string        `json:"name"`
+	Source        string        `json:"source"`
+	Page          int           `json:"page,omitempty"`
+	Time          []interface{} `json:"time,omitempty"` // Can be SpellTime-like objects or strings
+	Entries       []interface{} `json:"entries,omitempty"`
+	SeeAlsoAction []string      `json:"seeAlsoAction,omitempty"`
+}
+
+// SenseFile represents the structure of the senses.json file
+type SenseFile struct {
+	Sense []Sense `json:"sense"`
+}
+
+// Sense represents a single special sense such as darkvision
+type Sense struct {
+	Name    string        `json:"name"`
+	Source  string        `json:"source"`
+	Page    int           `json:"page,omitempty"`
+	Entries []interface{} `json:"entries,omitempty"`
+}
+
+// SkillFile represents the structure of the skills.json file
+type SkillFile struct {
+	Skill []Skill `json:"skill"`
+}
+
+// Skill represents a single skill and the ability it is based on
+type Skill struct {
+	Name    string        `json:"name"`
+	Source  string        `json:"source"`
+	Page    int           `json:"page,omitempty"`
+	Ability string        `json:"ability"`
+	Entries []interface{} `json:"entries,omitempty"`
+}
+
+// LanguageFile represents the structure of the languages.json file
+type LanguageFile struct {
+	Language []Language `json:"language"`
+}
+
+// Language represents a single language with its speakers and script
+type Language struct {
+	Name            string        `json:"name"`
+	Source          string        `json:"source"`
+	Page            int           `json:"page,omitempty"`
+	Type            string        `json:"type,omitempty"`
+	TypicalSpeakers []string      `json:"typicalSpeakers,omitempty"`
+	Script          string        `json:"script,omitempty"`
+	Dialects        []string      `json:"dialects,omitempty"`
+	Entries         []interface{} `json:"entries,omitempty"`
+}
+
+// parseActions parses the action data from the specified directory and writes it to the output directory.
+func parseActions(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Create output directory if it doesn't exist
+	outDir := filepath.Join(outDirectory, "actions")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Read and parse the action file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "actions.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read action file: %w", err)
+	}
+
+	var actionFile ActionFile
+	if err := json.Unmarshal(fileData, &actionFile); err != nil {
+		return fmt.Errorf("failed to parse action file: %w", err)
+	}
+
+	// Process each action
+	for _, action := range actionFile.Action {
+		mdContent, err := actionToMarkdown(action)
+		if err != nil {
+			return fmt.Errorf("failed to convert action to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, action.Name, mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseSenses parses the sense data from the specified directory and writes it to the output directory.
+func parseSenses(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Create output directory if it doesn't exist
+	outDir := filepath.Join(outDirectory, "senses")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Read and parse the sense file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "senses.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read sense file: %w", err)
+	}
+
+	var senseFile SenseFile
+	if err := json.Unmarshal(fileData, &senseFile); err != nil {
+		return fmt.Errorf("failed to parse sense file: %w", err)
+	}
+
+	// Process each sense
+	for _, sense := range senseFile.Sense {
+		mdContent, err := senseToMarkdown(sense)
+		if err != nil {
+			return fmt.Errorf("failed to convert sense to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, sense.Name, mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseSkills parses the skill data from the specified directory and writes it to the output directory.
+func parseSkills(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Create output directory if it doesn't exist
+	outDir := filepath.Join(outDirectory, "skills")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Read and parse the skill file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "skills.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read skill file: %w", err)
+	}
+
+	var skillFile SkillFile
+	if err := json.Unmarshal(fileData, &skillFile); err != nil {
+		return fmt.Errorf("failed to parse skill file: %w", err)
+	}
+
+	// Process each skill
+	for _, skill := range skillFile.Skill {
+		mdContent, err := skillToMarkdown(skill)
+		if err != nil {
+			return fmt.Errorf("failed to convert skill to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, skill.Name, mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseLanguages parses the language data from the specified directory and writes it to the output directory.
+func parseLanguages(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Create output directory if it doesn't exist
+	outDir := filepath.Join(outDirectory, "languages")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Read and parse the language file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "languages.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read language file: %w", err)
+	}
+
+	var languageFile LanguageFile
+	if err := json.Unmarshal(fileData, &languageFile); err != nil {
+		return fmt.Errorf("failed to parse language file: %w", err)
+	}
+
+	// Process each language
+	for _, language := range languageFile.Language {
+		mdContent, err := languageToMarkdown(language)
+		if err != nil {
+			return fmt.Errorf("failed to convert language to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, language.Name, mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// readLanguageNames reads the names of the languages in languages.json, keyed by lower case name. A missing
+// language file is not an error.
+func readLanguageNames(dataDirectory string) (map[string]bool, error) {
+	var languageFile LanguageFile
+	if err := readOptionalIndex(filepath.Join(dataDirectory, "languages.json"), &languageFile); err != nil {
+		return nil, fmt.Errorf("failed to read language file: %w", err)
+	}
+
+	names := make(map[string]bool)
+	for _, language := range languageFile.Language {
+		names[strings.ToLower(language.Name)] = true
+	}
+	return names, nil
+}
+
+// actionToMarkdown converts an action to Markdown format
+func actionToMarkdown(action Action) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", action.Name))
+	md.WriteString("*Action*\n\n")
+
+	// Time can be a list of {"number": 1, "unit": "action"} objects or free text such as "Varies"
+	if len(action.Time) > 0 {
+		times := make([]string, 0, len(action.Time))
+		for _, time := range action.Time {
+			switch t := time.(type) {
+			case string:
+				times = append(times, t)
+			case map[string]interface{}:
+				number, _ := t["number"].(float64)
+				unit, _ := t["unit"].(string)
+				times = append(times, fmt.Sprintf("%d %s", int(number), unit))
+			}
+		}
+		md.WriteString(fmt.Sprintf("**Time:** %s\n\n", strings.Join(times, ", ")))
+	}
+
+	// Description
+	entriesToMarkdown(&md, action.Entries, 2)
+
+	// Related actions
+	if len(action.SeeAlsoAction) > 0 {
+		links := make([]string, 0, len(action.SeeAlsoAction))
+		for _, seeAlso := range action.SeeAlsoAction {
+			links = append(links, linkToReference(seeAlso))
+		}
+		md.WriteString(fmt.Sprintf("**See Also:** %s\n\n", strings.Join(links, ", ")))
+	}
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", action.Source))
+	if action.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", action.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// senseToMarkdown converts a sense to Markdown format
+func senseToMarkdown(sense Sense) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", sense.Name))
+	md.WriteString("*Sense*\n\n")
+
+	// Description
+	entriesToMarkdown(&md, sense.Entries, 2)
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", sense.Source))
+	if sense.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", sense.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// skillToMarkdown converts a skill to Markdown format
+func skillToMarkdown(skill Skill) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", skill.Name))
+	if skill.Ability != "" {
+		md.WriteString(fmt.Sprintf("*Skill (%s)*\n\n", getAbilityName(skill.Ability)))
+	} else {
+		md.WriteString("*Skill*\n\n")
+	}
+
+	// Description
+	entriesToMarkdown(&md, skill.Entries, 2)
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", skill.Source))
+	if skill.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", skill.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// languageToMarkdown converts a language to Markdown format
+func languageToMarkdown(language Language) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", language.Name))
+	if language.Type != "" {
+		md.WriteString(fmt.Sprintf("*%s Language*\n\n", titleCase(language.Type)))
+	} else {
+		md.WriteString("*Language*\n\n")
+	}
+
+	// Speakers and script
+	if len(language.TypicalSpeakers) > 0 {
+		speakers := make([]string, 0, len(language.TypicalSpeakers))
+		for _, speaker := range language.TypicalSpeakers {
+			speakers = append(speakers, processSpecialFormatting(speaker))
+		}
+		md.WriteString(fmt.Sprintf("**Typical Speakers:** %s\n\n", strings.Join(speakers, ", ")))
+	}
+	if language.Script != "" {
+		md.WriteString(fmt.Sprintf("**Script:** %s\n\n", language.Script))
+	}
+	if len(language.Dialects) > 0 {
+		md.WriteString(fmt.Sprintf("**Dialects:** %s\n\n", strings.Join(language.Dialects, ", ")))
+	}
+
+	// Description
+	entriesToMarkdown(&md, language.Entries, 2)
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", language.Source))
+	if language.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", language.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
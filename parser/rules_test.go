@@ -0,0 +1,146 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestActionToMarkdown(t *testing.T) {
+	action := Action{
+		Name:   "Dash",
+		Source: "PHB",
+		Page:   192,
+		Time: []interface{}{
+			map[string]interface{}{"number": float64(1), "unit": "action"},
+		},
+		Entries:       []interface{}{"When you take the Dash action, you gain extra movement for the current turn."},
+		SeeAlsoAction: []string{"Disengage"},
+	}
+
+	md, err := actionToMarkdown(action)
+	if err != nil {
+		t.Fatalf("actionToMarkdown() error = %v", err)
+	}
+
+	expected := "# Dash\n\n*Action*\n\n**Time:** 1 action\n\nWhen you take the Dash action, you gain extra movement for the current turn.\n\n**See Also:** [[Disengage]]\n\n**Source:** PHB, page 192\n"
+	if md != expected {
+		t.Errorf("actionToMarkdown() = %q, want %q", md, expected)
+	}
+}
+
+func TestSkillToMarkdown(t *testing.T) {
+	skill := Skill{
+		Name:    "Athletics",
+		Source:  "PHB",
+		Page:    175,
+		Ability: "str",
+		Entries: []interface{}{"Your Strength (Athletics) check covers difficult situations."},
+	}
+
+	md, err := skillToMarkdown(skill)
+	if err != nil {
+		t.Fatalf("skillToMarkdown() error = %v", err)
+	}
+
+	expected := "# Athletics\n\n*Skill (Strength)*\n\nYour Strength (Athletics) check covers difficult situations.\n\n**Source:** PHB, page 175\n"
+	if md != expected {
+		t.Errorf("skillToMarkdown() = %q, want %q", md, expected)
+	}
+}
+
+func TestLanguageToMarkdown(t *testing.T) {
+	language := Language{
+		Name:            "Abyssal",
+		Source:          "PHB",
+		Page:            123,
+		Type:            "exotic",
+		TypicalSpeakers: []string{"Demons", "{@race tiefling|PHB|tieflings}"},
+		Script:          "Infernal",
+	}
+
+	md, err := languageToMarkdown(language)
+	if err != nil {
+		t.Fatalf("languageToMarkdown() error = %v", err)
+	}
+
+	expected := "# Abyssal\n\n*Exotic Language*\n\n**Typical Speakers:** Demons, tieflings\n\n**Script:** Infernal\n\n**Source:** PHB, page 123\n"
+	if md != expected {
+		t.Errorf("languageToMarkdown() = %q, want %q", md, expected)
+	}
+}
+
+func TestProcessRemainingTags(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Spoken by {@race elf|XPHB}.", "Spoken by elf."},
+		{"A {@i magical} {@b {@race dwarf||bearded folk}}.", "A *magical* **bearded folk**."},
+		{"See {@book chapter 5|DMG|5}.", "See chapter 5."},
+		{"No tags here.", "No tags here."},
+	}
+
+	for _, test := range tests {
+		result := processRemainingTags(test.input)
+		if result != test.expected {
+			t.Errorf("processRemainingTags(%q) = %q; want %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestParseRuleCatalogs_WithMockData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "rules-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	files := map[string]interface{}{
+		"actions.json":   ActionFile{Action: []Action{{Name: "Dash", Source: "PHB"}}},
+		"senses.json":    SenseFile{Sense: []Sense{{Name: "Darkvision", Source: "PHB"}}},
+		"skills.json":    SkillFile{Skill: []Skill{{Name: "Stealth", Source: "PHB", Ability: "dex"}}},
+		"languages.json": LanguageFile{Language: []Language{{Name: "Elvish", Source: "PHB", Type: "standard"}}},
+	}
+	for filename, content := range files {
+		data, err := json.Marshal(content)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s: %v", filename, err)
+		}
+		if err := os.WriteFile(filepath.Join(dataDir, filename), data, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", filename, err)
+		}
+	}
+
+	ctx := context.Background()
+	for name, parse := range map[string]func(context.Context, string, string) error{
+		"parseActions":   parseActions,
+		"parseSenses":    parseSenses,
+		"parseSkills":    parseSkills,
+		"parseLanguages": parseLanguages,
+	} {
+		if err := parse(ctx, dataDir, outDir); err != nil {
+			t.Fatalf("%s() error = %v", name, err)
+		}
+	}
+
+	expectedFiles := []string{
+		filepath.Join("actions", "Dash.md"),
+		filepath.Join("senses", "Darkvision.md"),
+		filepath.Join("skills", "Stealth.md"),
+		filepath.Join("languages", "Elvish.md"),
+	}
+	for _, expectedFile := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(outDir, expectedFile)); os.IsNotExist(err) {
+			t.Errorf("Expected file %s does not exist", expectedFile)
+		}
+	}
+}
@@ -0,0 +1,193 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// The publication groups that Config.IncludeGroups and Config.ExcludeGroups can select
+const (
+	GroupCore        = "core"
+	GroupSupplements = "supplements"
+	GroupAdventures  = "adventures"
+	GroupPartnered   = "partnered"
+	GroupPlaytest    = "playtest"
+	GroupHomebrew    = "homebrew"
+)
+
+// bookGroups maps the group of a book in books.json to its publication group
+var bookGroups = map[string]string{
+	"core":           GroupCore,
+	"supplement":     GroupSupplements,
+	"supplement-alt": GroupSupplements,
+	"setting":        GroupSupplements,
+	"setting-alt":    GroupSupplements,
+	"screen":         GroupSupplements,
+	"recipe":         GroupSupplements,
+	"other":          GroupSupplements,
+	"partnered":      GroupPartnered,
+	"prerelease":     GroupPlaytest,
+	"homebrew":       GroupHomebrew,
+}
+
+// sourceFilter decides which sources spells, monsters and items are written from. A nil filter keeps every
+// source.
+type sourceFilter struct {
+	includeSources []string
+	excludeSources []string
+	includeGroups  map[string]bool
+	excludeGroups  map[string]bool
+	groups         map[string]string // The publication group of each upper case source in the book indexes
+}
+
+// newSourceFilter creates a filter for the given source abbreviations and publication groups. Sources such as
+// "UA*" match every source starting with "UA". The publication groups come from books.json and adventures.json,
+// which are only read when filtering by group. It returns nil when there is nothing to filter.
+func newSourceFilter(dataDirectory string, includeSources, excludeSources, includeGroups, excludeGroups []string) (*sourceFilter, error) {
+	if len(includeSources) == 0 && len(excludeSources) == 0 && len(includeGroups) == 0 && len(excludeGroups) == 0 {
+		return nil, nil
+	}
+
+	filter := &sourceFilter{
+		includeSources: includeSources,
+		excludeSources: excludeSources,
+		includeGroups:  make(map[string]bool),
+		excludeGroups:  make(map[string]bool),
+	}
+	for _, group := range includeGroups {
+		filter.includeGroups[strings.ToLower(group)] = true
+	}
+	for _, group := range excludeGroups {
+		filter.excludeGroups[strings.ToLower(group)] = true
+	}
+
+	if len(includeGroups) > 0 || len(excludeGroups) > 0 {
+		groups, err := readSourceGroups(dataDirectory)
+		if err != nil {
+			return nil, err
+		}
+		filter.groups = groups
+	}
+
+	return filter, nil
+}
+
+// readSourceGroups reads the publication group of every book and adventure. Adventures are in the adventures
+// group unless they were published by a partner. A missing index file is not an error.
+func readSourceGroups(dataDirectory string) (map[string]string, error) {
+	books, adventures, err := readBookIndexes(dataDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make(map[string]string)
+	for _, book := range books {
+		if group, ok := bookGroups[book.Group]; ok {
+			groups[strings.ToUpper(book.Source)] = group
+		}
+	}
+	for _, adventure := range adventures {
+		group := GroupAdventures
+		if bookGroups[adventure.Group] == GroupPartnered {
+			group = GroupPartnered
+		}
+		groups[strings.ToUpper(adventure.Source)] = group
+	}
+
+	return groups, nil
+}
+
+// readSourcePublished reads the publication date of every book and adventure, e.g. "2014-08-19", keyed by upper
+// case source. A missing index file is not an error.
+func readSourcePublished(dataDirectory string) (map[string]string, error) {
+	books, adventures, err := readBookIndexes(dataDirectory)
+	if err != nil {
+		return nil, err
+	}
+
+	published := make(map[string]string)
+	for _, book := range append(books, adventures...) {
+		published[strings.ToUpper(book.Source)] = book.Published
+	}
+	return published, nil
+}
+
+// readBookIndexes reads the books and adventures in books.json and adventures.json
+func readBookIndexes(dataDirectory string) ([]BookInfo, []BookInfo, error) {
+	var bookIndex BookIndexFile
+	if err := readOptionalIndex(filepath.Join(dataDirectory, sourcebookKind.indexFile), &bookIndex); err != nil {
+		return nil, nil, fmt.Errorf("failed to read book index file: %w", err)
+	}
+
+	var adventureIndex AdventureIndexFile
+	if err := readOptionalIndex(filepath.Join(dataDirectory, adventureKind.indexFile), &adventureIndex); err != nil {
+		return nil, nil, fmt.Errorf("failed to read adventure index file: %w", err)
+	}
+
+	return bookIndex.Book, adventureIndex.Adventure, nil
+}
+
+// readOptionalIndex parses a JSON index file into v, leaving v empty when the file doesn't exist
+func readOptionalIndex(path string, v interface{}) error {
+	fileData, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(fileData, v)
+}
+
+// group returns the publication group of a source. Unearthed Arcana and other sources missing from the book
+// indexes are playtest material and homebrew respectively.
+func (f *sourceFilter) group(source string) string {
+	source = strings.ToUpper(source)
+	if group, ok := f.groups[source]; ok {
+		return group
+	}
+	if strings.HasPrefix(source, "UA") {
+		return GroupPlaytest
+	}
+	return GroupHomebrew
+}
+
+// keep reports whether things from a source should be written. A source is kept when it matches one of the
+// included sources or groups, or nothing is included, and it matches none of the excluded ones.
+func (f *sourceFilter) keep(source string) bool {
+	if f == nil {
+		return true
+	}
+
+	var group string
+	if len(f.includeGroups) > 0 || len(f.excludeGroups) > 0 {
+		group = f.group(source)
+	}
+
+	if matchesSource(source, f.excludeSources) || f.excludeGroups[group] {
+		return false
+	}
+	if len(f.includeSources) == 0 && len(f.includeGroups) == 0 {
+		return true
+	}
+	return matchesSource(source, f.includeSources) || f.includeGroups[group]
+}
+
+// matchesSource reports whether a source matches any of the patterns, e.g. "PHB" or "UA*"
+func matchesSource(source string, patterns []string) bool {
+	source = strings.ToUpper(source)
+	for _, pattern := range patterns {
+		pattern = strings.ToUpper(pattern)
+		if prefix, ok := strings.CutSuffix(pattern, "*"); ok {
+			if strings.HasPrefix(source, prefix) {
+				return true
+			}
+		} else if source == pattern {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,197 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestMatchesSource(t *testing.T) {
+	tests := []struct {
+		source   string
+		patterns []string
+		expected bool
+	}{
+		{"PHB", []string{"PHB"}, true},
+		{"phb", []string{"PHB"}, true},
+		{"XPHB", []string{"PHB"}, false},
+		{"UA2020SubclassesPt1", []string{"UA*"}, true},
+		{"MM", []string{"UA*", "PHB"}, false},
+		{"MM", nil, false},
+	}
+
+	for _, tt := range tests {
+		if result := matchesSource(tt.source, tt.patterns); result != tt.expected {
+			t.Errorf("matchesSource(%q, %v) = %v, want %v", tt.source, tt.patterns, result, tt.expected)
+		}
+	}
+}
+
+func TestSourceFilter_Keep(t *testing.T) {
+	groups := map[string]string{"PHB": GroupCore, "XGE": GroupSupplements, "LMOP": GroupAdventures}
+
+	tests := []struct {
+		name     string
+		filter   *sourceFilter
+		expected map[string]bool
+	}{
+		{
+			name:     "No filter",
+			filter:   nil,
+			expected: map[string]bool{"PHB": true, "HOMEBREW": true},
+		},
+		{
+			name:     "Include sources",
+			filter:   &sourceFilter{includeSources: []string{"PHB", "UA*"}},
+			expected: map[string]bool{"PHB": true, "UAArtificer": true, "XGE": false},
+		},
+		{
+			name:     "Exclude sources",
+			filter:   &sourceFilter{excludeSources: []string{"UA*"}},
+			expected: map[string]bool{"PHB": true, "UAArtificer": false},
+		},
+		{
+			name:     "Include groups",
+			filter:   &sourceFilter{includeGroups: map[string]bool{GroupCore: true}, groups: groups},
+			expected: map[string]bool{"PHB": true, "XGE": false, "LMOP": false},
+		},
+		{
+			name:     "Include group and source",
+			filter:   &sourceFilter{includeSources: []string{"XGE"}, includeGroups: map[string]bool{GroupCore: true}, groups: groups},
+			expected: map[string]bool{"PHB": true, "XGE": true, "LMOP": false},
+		},
+		{
+			name:     "Exclude groups",
+			filter:   &sourceFilter{excludeGroups: map[string]bool{GroupAdventures: true, GroupPlaytest: true, GroupHomebrew: true}, groups: groups},
+			expected: map[string]bool{"PHB": true, "LMOP": false, "UAArtificer": false, "HOMEBREW": false},
+		},
+		{
+			name:     "Exclusions win",
+			filter:   &sourceFilter{excludeSources: []string{"XGE"}, includeGroups: map[string]bool{GroupSupplements: true}, groups: groups},
+			expected: map[string]bool{"XGE": false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for source, expected := range tt.expected {
+				if result := tt.filter.keep(source); result != expected {
+					t.Errorf("keep(%q) = %v, want %v", source, result, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestNewSourceFilter(t *testing.T) {
+	dataDir, err := os.MkdirTemp("", "sources-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(dataDir)
+
+	files := map[string]interface{}{
+		"books.json": BookIndexFile{Book: []BookInfo{
+			{Name: "Player's Handbook", ID: "PHB", Source: "PHB", Group: "core"},
+			{Name: "Eberron: Rising from the Last War", ID: "ERLW", Source: "ERLW", Group: "setting"},
+		}},
+		"adventures.json": AdventureIndexFile{Adventure: []BookInfo{
+			{Name: "Lost Mine of Phandelver", ID: "LMoP", Source: "LMoP", Group: "supplement"},
+			{Name: "Partnered Adventure", ID: "PA", Source: "PA", Group: "partnered"},
+		}},
+	}
+	for name, content := range files {
+		data, err := json.Marshal(content)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dataDir, name), data, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	if filter, err := newSourceFilter(dataDir, nil, nil, nil, nil); err != nil || filter != nil {
+		t.Errorf("newSourceFilter() without sources = %v, %v, want nil", filter, err)
+	}
+
+	filter, err := newSourceFilter(dataDir, nil, nil, []string{"Core", "supplements"}, nil)
+	if err != nil {
+		t.Fatalf("newSourceFilter() error = %v", err)
+	}
+
+	expectedGroups := map[string]string{
+		"PHB":  GroupCore,
+		"ERLW": GroupSupplements,
+		"lmop": GroupAdventures,
+		"PA":   GroupPartnered,
+		"UA1":  GroupPlaytest,
+		"XYZ":  GroupHomebrew,
+	}
+	for source, expected := range expectedGroups {
+		if group := filter.group(source); group != expected {
+			t.Errorf("group(%q) = %q, want %q", source, group, expected)
+		}
+	}
+
+	for source, expected := range map[string]bool{"PHB": true, "ERLW": true, "LMoP": false} {
+		if result := filter.keep(source); result != expected {
+			t.Errorf("keep(%q) = %v, want %v", source, result, expected)
+		}
+	}
+}
+
+func TestParseSpells_WithSourceFilter(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "source-filter-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	if err := os.MkdirAll(filepath.Join(dataDir, "spells"), 0755); err != nil {
+		t.Fatalf("Failed to create spells dir: %v", err)
+	}
+
+	files := map[string]interface{}{
+		"index.json": map[string]string{"PHB": "spells-phb.json", "XPHB": "spells-xphb.json", "UA": "spells-ua.json"},
+		"spells-phb.json": SpellFile{Spell: []Spell{
+			{Name: "Fireball", Source: "PHB", Level: 3, School: "V", ReprintedAs: []interface{}{"Fireball|XPHB"}},
+		}},
+		"spells-xphb.json": SpellFile{Spell: []Spell{{Name: "Fireball", Source: "XPHB", Level: 3, School: "V"}}},
+		"spells-ua.json":   SpellFile{Spell: []Spell{{Name: "Playtest Bolt", Source: "UAPlaytest", Level: 1, School: "V"}}},
+	}
+	for name, content := range files {
+		data, err := json.Marshal(content)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dataDir, "spells", name), data, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	// The 2024 edition is preferred, but the reprint's source is excluded, so the original is kept
+	filter := &sourceFilter{excludeSources: []string{"XPHB", "UA*"}}
+	if err := parseSpells(context.Background(), dataDir, outDir, Edition2024, filter); err != nil {
+		t.Fatalf("parseSpells() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outDir, "spells", "Fireball.md"))
+	if err != nil {
+		t.Fatalf("Failed to read Fireball.md: %v", err)
+	}
+	if !strings.Contains(string(content), "**Source:** PHB") {
+		t.Errorf("Fireball.md isn't the PHB printing, got %s", content)
+	}
+	if strings.Contains(string(content), "[!note] Reprinted") {
+		t.Errorf("Fireball.md links to an excluded reprint, got %s", content)
+	}
+
+	if _, err := os.Stat(filepath.Join(outDir, "spells", "Playtest Bolt.md")); err == nil {
+		t.Errorf("Playtest Bolt.md written from an excluded source")
+	}
+}
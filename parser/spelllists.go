@@ -0,0 +1,205 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// writeSpellLists writes an index note for each class and subclass spell list, e.g. "Wizard Spells" or
+// "Cleric (Light Domain) Spells", linking to the spell notes
+func writeSpellLists(outDirectory string, spells []Spell) error {
+	lists := buildSpellLists(spells)
+	costly := costlyComponentSpells(spells)
+	if len(lists) == 0 && len(costly) == 0 {
+		return nil
+	}
+
+	// Create output directory if it doesn't exist
+	outDir := filepath.Join(outDirectory, "spell-lists")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	for _, name := range sortedKeys(lists) {
+		mdContent, err := spellListToMarkdown(name, lists[name])
+		if err != nil {
+			return fmt.Errorf("failed to convert spell list to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, name+" Spells", mdContent); err != nil {
+			return err
+		}
+	}
+
+	if len(costly) > 0 {
+		mdContent, err := costlyComponentsToMarkdown(costly)
+		if err != nil {
+			return fmt.Errorf("failed to convert costly components to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, "Costly Material Components", mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildSpellLists groups spells by the classes and subclasses that have them on their list. Classes printed in
+// several books share a list, and printings of a spell written to the same note are only listed once.
+func buildSpellLists(spells []Spell) map[string][]Spell {
+	lists := make(map[string][]Spell)
+	seen := make(map[string]bool)
+	add := func(list string, spell Spell) {
+		key := strings.ToLower(list + "|" + spellNoteName(spell))
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		lists[list] = append(lists[list], spell)
+	}
+
+	for _, spell := range spells {
+		for _, class := range spellClassNames(spell.Classes.FromClassList) {
+			add(class, spell)
+		}
+		for _, subclass := range spellSubclassNames(spell.Classes.FromSubclass) {
+			add(subclass, spell)
+		}
+	}
+	return lists
+}
+
+// spellListToMarkdown converts a class or subclass spell list to Markdown format, with a table of spells per
+// level. The school column lets the tables be sorted by school.
+func spellListToMarkdown(name string, spells []Spell) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s Spells\n\n", name))
+	md.WriteString("*Spell List*\n\n")
+
+	byLevel := make(map[int][]Spell)
+	for _, spell := range spells {
+		byLevel[spell.Level] = append(byLevel[spell.Level], spell)
+	}
+
+	for level := 0; level <= 9; level++ {
+		levelSpells := byLevel[level]
+		if len(levelSpells) == 0 {
+			continue
+		}
+		sort.Slice(levelSpells, func(i, j int) bool {
+			return strings.ToLower(spellNoteName(levelSpells[i])) < strings.ToLower(spellNoteName(levelSpells[j]))
+		})
+
+		if level == 0 {
+			md.WriteString("## Cantrips\n\n")
+		} else {
+			md.WriteString(fmt.Sprintf("## Level %d\n\n", level))
+		}
+
+		md.WriteString("| Spell | School | Concentration | Ritual |\n")
+		md.WriteString("| --- | --- | --- | --- |\n")
+		for _, spell := range levelSpells {
+			concentration, ritual := "", ""
+			if isConcentrationSpell(spell) {
+				concentration = "Yes"
+			}
+			if isRitualSpell(spell) {
+				ritual = "Yes"
+			}
+			md.WriteString(fmt.Sprintf("| %s | %s | %s | %s |\n",
+				strings.ReplaceAll(linkTo(spellNoteName(spell)), "|", "\\|"), getSchoolName(spell.School), concentration, ritual))
+		}
+		md.WriteString("\n")
+	}
+
+	return md.String(), nil
+}
+
+// spellNoteName returns the name of the note a spell is written to, e.g. "Fireball (PHB)" for the older printing
+// when both editions are written
+func spellNoteName(spell Spell) string {
+	if spell.NoteName != "" {
+		return spell.NoteName
+	}
+	return spell.Name
+}
+
+// isConcentrationSpell reports whether a spell requires concentration
+func isConcentrationSpell(spell Spell) bool {
+	for _, duration := range spell.Duration {
+		if duration.Concentration || duration.Type == "concentration" {
+			return true
+		}
+	}
+	return false
+}
+
+// isRitualSpell reports whether a spell can be cast as a ritual
+func isRitualSpell(spell Spell) bool {
+	ritual, _ := spell.Meta["ritual"].(bool)
+	return ritual
+}
+
+// costlyComponentSpells returns the spells whose material components have a cost or are consumed, most
+// expensive first. Printings of a spell written to the same note are only listed once.
+func costlyComponentSpells(spells []Spell) []Spell {
+	var costly []Spell
+	seen := make(map[string]bool)
+	for _, spell := range spells {
+		key := strings.ToLower(spellNoteName(spell))
+		material, ok := getSpellMaterial(spell.Components)
+		if !ok || (material.cost == 0 && material.consume == "") || seen[key] {
+			continue
+		}
+		seen[key] = true
+		costly = append(costly, spell)
+	}
+
+	sort.SliceStable(costly, func(i, j int) bool {
+		a, _ := getSpellMaterial(costly[i].Components)
+		b, _ := getSpellMaterial(costly[j].Components)
+		if a.cost != b.cost {
+			return a.cost > b.cost
+		}
+		return strings.ToLower(spellNoteName(costly[i])) < strings.ToLower(spellNoteName(costly[j]))
+	})
+	return costly
+}
+
+// costlyComponentsToMarkdown converts the spells with costly or consumed material components to a Markdown
+// index, so the party knows which components to buy
+func costlyComponentsToMarkdown(spells []Spell) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString("# Costly Material Components\n\n")
+	md.WriteString("*Spell List*\n\n")
+
+	md.WriteString("| Spell | Level | Component | Cost | Consumed |\n")
+	md.WriteString("| --- | --- | --- | --- | --- |\n")
+	for _, spell := range spells {
+		material, _ := getSpellMaterial(spell.Components)
+		cost := ""
+		if material.cost > 0 {
+			cost = formatCoins(material.cost)
+		}
+		consumed := ""
+		switch material.consume {
+		case "consumed":
+			consumed = "Yes"
+		case "may be consumed":
+			consumed = "Optional"
+		}
+		md.WriteString(fmt.Sprintf("| %s | %d | %s | %s | %s |\n",
+			strings.ReplaceAll(linkTo(spellNoteName(spell)), "|", "\\|"), spell.Level, strings.ReplaceAll(processSpecialFormatting(material.text), "|", "\\|"), cost, consumed))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
@@ -0,0 +1,178 @@
+package parser
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testSpellListSpells() []Spell {
+	wizard := SpellClasses{FromClassList: []SpellClass{{Name: "Wizard", Source: "PHB"}}}
+	return []Spell{
+		{Name: "Fireball", Source: "PHB", Level: 3, School: "V", Classes: wizard},
+		{
+			Name:   "Detect Magic",
+			Source: "PHB",
+			Level:  1,
+			School: "D",
+			Meta:   map[string]interface{}{"ritual": true},
+			Duration: []SpellDuration{
+				{Type: "timed", Concentration: true, Duration: map[string]interface{}{"type": "minute", "amount": float64(10)}},
+			},
+			Classes: SpellClasses{
+				FromClassList: []SpellClass{{Name: "Wizard", Source: "PHB"}, {Name: "Cleric", Source: "PHB"}},
+				FromSubclass: []SpellSubclass{
+					{Class: SpellClass{Name: "Cleric", Source: "PHB"}, Subclass: SpellSubclassInfo{Name: "Knowledge Domain"}},
+				},
+			},
+		},
+		{Name: "Fire Bolt", Source: "PHB", Level: 0, School: "V", Classes: wizard},
+		// The 2024 reprint shares the Wizard list without being listed twice
+		{Name: "Fireball", Source: "XPHB", Level: 3, School: "V", Classes: SpellClasses{FromClassList: []SpellClass{{Name: "Wizard", Source: "XPHB"}}}},
+	}
+}
+
+func TestBuildSpellLists(t *testing.T) {
+	lists := buildSpellLists(testSpellListSpells())
+
+	expected := map[string]int{
+		"Wizard":                    3,
+		"Cleric":                    1,
+		"Cleric (Knowledge Domain)": 1,
+	}
+	if len(lists) != len(expected) {
+		t.Errorf("buildSpellLists() returned %d lists, want %d", len(lists), len(expected))
+	}
+	for name, count := range expected {
+		if len(lists[name]) != count {
+			t.Errorf("buildSpellLists()[%q] has %d spells, want %d", name, len(lists[name]), count)
+		}
+	}
+}
+
+func TestSpellListToMarkdown(t *testing.T) {
+	lists := buildSpellLists(testSpellListSpells())
+
+	result, err := spellListToMarkdown("Wizard", lists["Wizard"])
+	if err != nil {
+		t.Fatalf("spellListToMarkdown() error = %v", err)
+	}
+
+	expected := "# Wizard Spells\n\n" +
+		"*Spell List*\n\n" +
+		"## Cantrips\n\n" +
+		"| Spell | School | Concentration | Ritual |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| [[Fire Bolt]] | Evocation |  |  |\n\n" +
+		"## Level 1\n\n" +
+		"| Spell | School | Concentration | Ritual |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| [[Detect Magic]] | Divination | Yes | Yes |\n\n" +
+		"## Level 3\n\n" +
+		"| Spell | School | Concentration | Ritual |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| [[Fireball]] | Evocation |  |  |\n\n"
+	if result != expected {
+		t.Errorf("spellListToMarkdown() = %q, want %q", result, expected)
+	}
+}
+
+func TestWriteSpellLists(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "spell-lists-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	if err := writeSpellLists(tempDir, testSpellListSpells()); err != nil {
+		t.Fatalf("writeSpellLists() error = %v", err)
+	}
+
+	expectedFiles := []string{"Cleric (Knowledge Domain) Spells.md", "Cleric Spells.md", "Wizard Spells.md"}
+	files, err := os.ReadDir(filepath.Join(tempDir, "spell-lists"))
+	if err != nil {
+		t.Fatalf("Failed to read output directory: %v", err)
+	}
+	var names []string
+	for _, file := range files {
+		names = append(names, file.Name())
+	}
+	if strings.Join(names, ",") != strings.Join(expectedFiles, ",") {
+		t.Errorf("writeSpellLists() wrote %v, want %v", names, expectedFiles)
+	}
+}
+
+func TestCostlyComponentsToMarkdown(t *testing.T) {
+	spells := []Spell{
+		{Name: "Fireball", Level: 3, Components: SpellComponents{M: "a tiny ball of bat guano and sulfur"}},
+		{Name: "Identify", Level: 1, Components: SpellComponents{M: map[string]interface{}{"text": "a pearl worth at least 100 gp", "cost": float64(10000)}}},
+		{Name: "Revivify", Level: 3, Components: SpellComponents{M: map[string]interface{}{"text": "diamonds worth 300 gp, which the spell consumes", "cost": float64(30000), "consume": true}}},
+		{Name: "Revivify", Source: "XPHB", Level: 3, Components: SpellComponents{M: map[string]interface{}{"text": "a diamond worth 300+ GP, which the spell consumes", "cost": float64(30000), "consume": true}}},
+	}
+
+	costly := costlyComponentSpells(spells)
+	result, err := costlyComponentsToMarkdown(costly)
+	if err != nil {
+		t.Fatalf("costlyComponentsToMarkdown() error = %v", err)
+	}
+
+	expected := "# Costly Material Components\n\n" +
+		"*Spell List*\n\n" +
+		"| Spell | Level | Component | Cost | Consumed |\n" +
+		"| --- | --- | --- | --- | --- |\n" +
+		"| [[Revivify]] | 3 | diamonds worth 300 gp, which the spell consumes | 300 gp | Yes |\n" +
+		"| [[Identify]] | 1 | a pearl worth at least 100 gp | 100 gp |  |\n\n"
+	if result != expected {
+		t.Errorf("costlyComponentsToMarkdown() = %q, want %q", result, expected)
+	}
+}
+
+func TestBuildSpellLists_BothEditions(t *testing.T) {
+	spells := testSpellListSpells()
+	// Both editions are written, so the older printing has its own note
+	spells[0].NoteName = "Fireball (PHB)"
+
+	lists := buildSpellLists(spells)
+	result, err := spellListToMarkdown("Wizard", lists["Wizard"])
+	if err != nil {
+		t.Fatalf("spellListToMarkdown() error = %v", err)
+	}
+
+	expected := "## Level 3\n\n" +
+		"| Spell | School | Concentration | Ritual |\n" +
+		"| --- | --- | --- | --- |\n" +
+		"| [[Fireball]] | Evocation |  |  |\n" +
+		"| [[Fireball (PHB)]] | Evocation |  |  |\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("spellListToMarkdown() missing %q, got %s", expected, result)
+	}
+}
+
+func TestSpellListToMarkdown_UnsafeName(t *testing.T) {
+	spell := Spell{
+		Name:       "Antipathy/Sympathy",
+		Level:      8,
+		School:     "E",
+		Components: SpellComponents{M: map[string]interface{}{"text": "alum soaked in vinegar", "cost": float64(100)}},
+	}
+
+	// The alias separator in the link is escaped so it doesn't split the table cell
+	expected := "| [[Antipathy-Sympathy\\|Antipathy/Sympathy]] | Enchantment |  |  |\n"
+	result, err := spellListToMarkdown("Wizard", []Spell{spell})
+	if err != nil {
+		t.Fatalf("spellListToMarkdown() error = %v", err)
+	}
+	if !strings.Contains(result, expected) {
+		t.Errorf("spellListToMarkdown() missing %q, got %s", expected, result)
+	}
+
+	expected = "| [[Antipathy-Sympathy\\|Antipathy/Sympathy]] | 8 | alum soaked in vinegar | 1 gp |  |\n"
+	result, err = costlyComponentsToMarkdown([]Spell{spell})
+	if err != nil {
+		t.Fatalf("costlyComponentsToMarkdown() error = %v", err)
+	}
+	if !strings.Contains(result, expected) {
+		t.Errorf("costlyComponentsToMarkdown() missing %q, got %s", expected, result)
+	}
+}
@@ -0,0 +1,343 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// spellAreaNames are the shapes a spell can affect, from the areaTags field
+var spellAreaNames = map[string]string{
+	"ST": "Single Target",
+	"MT": "Multiple Targets",
+	"C":  "Cube",
+	"N":  "Cone",
+	"Y":  "Cylinder",
+	"S":  "Sphere",
+	"R":  "Circle",
+	"Q":  "Square",
+	"L":  "Line",
+	"H":  "Hemisphere",
+	"W":  "Wall",
+}
+
+// spellMiscTagNames describe the miscTags field
+var spellMiscTagNames = map[string]string{
+	"AAD":  "Additional Attack Damage",
+	"ADV":  "Grants Advantage",
+	"DFT":  "Difficult Terrain",
+	"FMV":  "Forced Movement",
+	"HL":   "Healing",
+	"LGT":  "Creates Light",
+	"LGTS": "Creates Sunlight",
+	"MAC":  "Modifies AC",
+	"OBJ":  "Affects Objects",
+	"OBS":  "Obscures Vision",
+	"PIR":  "Permanent If Repeated",
+	"PRM":  "Permanent Effects",
+	"PS":   "Plane Shifting",
+	"RO":   "Rollable Effects",
+	"SCL":  "Scaling Effects",
+	"SGT":  "Requires Sight",
+	"SMN":  "Summons Creature",
+	"THP":  "Grants Temporary Hit Points",
+	"TP":   "Teleportation",
+	"UBA":  "Uses Bonus Action",
+}
+
+// spellAttackNames describe the spellAttack field
+var spellAttackNames = map[string]string{
+	"M": "Melee",
+	"R": "Ranged",
+	"O": "Other",
+}
+
+// spellTagNames resolves a list of tag codes to their names, keeping unknown codes as they are
+func spellTagNames(codes []string, names map[string]string) []string {
+	result := make([]string, 0, len(codes))
+	for _, code := range codes {
+		if name, ok := names[strings.ToUpper(code)]; ok {
+			result = append(result, name)
+		} else {
+			result = append(result, code)
+		}
+	}
+	return result
+}
+
+// writeSpellFrontmatter writes the spell's level, school and tags as Obsidian properties, so spells can be
+// filtered and queried
+func writeSpellFrontmatter(md *strings.Builder, spell Spell) {
+	md.WriteString("---\n")
+	md.WriteString(fmt.Sprintf("level: %d\n", spell.Level))
+	md.WriteString(fmt.Sprintf("school: %s\n", getSchoolName(spell.School)))
+	md.WriteString(fmt.Sprintf("ritual: %t\n", isRitualSpell(spell)))
+	md.WriteString(fmt.Sprintf("concentration: %t\n", isConcentrationSpell(spell)))
+	if material, ok := getSpellMaterial(spell.Components); ok {
+		if material.cost > 0 {
+			md.WriteString(fmt.Sprintf("materialCost: %s\n", strconv.FormatFloat(material.cost/100, 'f', -1, 64)))
+		}
+		if material.consume != "" {
+			md.WriteString("materialConsumed: true\n")
+		}
+	}
+
+	lists := []struct {
+		key    string
+		values []string
+	}{
+		{"area", spellTagNames(spell.AreaTags, spellAreaNames)},
+		{"attack", spellTagNames(spell.SpellAttack, spellAttackNames)},
+		{"damage", spell.DamageInflict},
+		{"damageResist", spell.DamageResist},
+		{"conditions", spell.ConditionInflict},
+		{"savingThrow", spell.SavingThrow},
+		{"abilityCheck", spell.AbilityCheck},
+		{"tags", spellTagNames(spell.MiscTags, spellMiscTagNames)},
+	}
+	for _, list := range lists {
+		if len(list.values) == 0 {
+			continue
+		}
+		md.WriteString(fmt.Sprintf("%s:\n", list.key))
+		for _, value := range list.values {
+			md.WriteString(fmt.Sprintf("  - %s\n", strings.ToLower(value)))
+		}
+	}
+	md.WriteString("---\n\n")
+}
+
+// writeSpellTags writes the spell's area, attack type, inflicted conditions and other tags
+func writeSpellTags(md *strings.Builder, spell Spell) {
+	if len(spell.AreaTags) > 0 {
+		md.WriteString(fmt.Sprintf("**Area:** %s\n\n", strings.Join(spellTagNames(spell.AreaTags, spellAreaNames), ", ")))
+	}
+	if len(spell.SpellAttack) > 0 {
+		md.WriteString(fmt.Sprintf("**Spell Attack:** %s\n\n", strings.Join(spellTagNames(spell.SpellAttack, spellAttackNames), ", ")))
+	}
+	if len(spell.ConditionInflict) > 0 {
+		var links []string
+		for _, condition := range spell.ConditionInflict {
+			links = append(links, linkTo(titleCase(condition)))
+		}
+		md.WriteString(fmt.Sprintf("**Conditions:** %s\n\n", strings.Join(links, ", ")))
+	}
+	if len(spell.DamageResist) > 0 {
+		md.WriteString(fmt.Sprintf("**Damage Resistance:** %s\n\n", strings.Join(spell.DamageResist, ", ")))
+	}
+	if len(spell.AbilityCheck) > 0 {
+		md.WriteString(fmt.Sprintf("**Ability Check:** %s\n\n", strings.Join(spell.AbilityCheck, ", ")))
+	}
+	if len(spell.MiscTags) > 0 {
+		md.WriteString(fmt.Sprintf("**Tags:** %s\n\n", strings.Join(spellTagNames(spell.MiscTags, spellMiscTagNames), ", ")))
+	}
+}
+
+// spellTimeUnits are the names of the casting time units, in the singular
+var spellTimeUnits = map[string]string{
+	"action":   "action",
+	"bonus":    "bonus action",
+	"reaction": "reaction",
+	"round":    "round",
+	"minute":   "minute",
+	"hour":     "hour",
+}
+
+// spellAreaTypes are the range types that describe an area around the caster, e.g. "Self (15-foot cone)"
+var spellAreaTypes = map[string]bool{
+	"line":       true,
+	"cube":       true,
+	"cone":       true,
+	"emanation":  true,
+	"radius":     true,
+	"sphere":     true,
+	"hemisphere": true,
+	"cylinder":   true,
+}
+
+// spellDurationEnds describe how a permanent spell can end
+var spellDurationEnds = map[string]string{
+	"dispel":    "dispelled",
+	"trigger":   "triggered",
+	"discharge": "discharged",
+}
+
+// formatAmount formats an amount of a unit, e.g. "1 minute" but "10 minutes"
+func formatAmount(amount int, unit string) string {
+	return fmt.Sprintf("%d %s", amount, pluralize(amount, unit, unit+"s"))
+}
+
+// formatSpellTime formats a casting time, e.g. "1 bonus action" or "1 reaction, which you take when you see a
+// creature within 60 feet of you casting a spell"
+func formatSpellTime(time SpellTime) string {
+	if time.Unit == "special" {
+		return "Special"
+	}
+
+	unit, ok := spellTimeUnits[time.Unit]
+	if !ok {
+		unit = time.Unit
+	}
+	text := formatAmount(time.Number, unit)
+	if time.Condition != "" {
+		text += ", " + processSpecialFormatting(time.Condition)
+	}
+	if time.Note != "" {
+		text += " " + processSpecialFormatting(time.Note)
+	}
+	return text
+}
+
+// formatSpellRange formats a spell's range, e.g. "150 feet", "Touch", "1 mile" or "Self (15-foot cone)"
+func formatSpellRange(spellRange SpellRange) string {
+	distance := spellRange.Distance
+	switch {
+	case spellRange.Type == "special":
+		return "Special"
+	case spellRange.Type == "point":
+		return formatSpellDistance(distance)
+	case spellAreaTypes[spellRange.Type]:
+		// Areas read "15-foot cone", "30-foot radius" or "10-foot-radius sphere"
+		size := fmt.Sprintf("%d-%s", distance.Amount, singularDistanceUnit(distance.Type))
+		switch spellRange.Type {
+		case "sphere", "hemisphere", "cylinder":
+			return fmt.Sprintf("Self (%s-radius %s)", size, spellRange.Type)
+		default:
+			return fmt.Sprintf("Self (%s %s)", size, spellRange.Type)
+		}
+	default:
+		return spellRange.Type
+	}
+}
+
+// formatSpellDistance formats the distance of a point range, e.g. "Self", "60 feet" or "Unlimited"
+func formatSpellDistance(distance SpellRangeDetail) string {
+	switch distance.Type {
+	case "self":
+		return "Self"
+	case "touch":
+		return "Touch"
+	case "sight":
+		return "Sight"
+	case "unlimited":
+		return "Unlimited"
+	case "plane":
+		return "Unlimited (same plane)"
+	case "feet", "miles":
+		if distance.Amount == 1 {
+			return fmt.Sprintf("1 %s", singularDistanceUnit(distance.Type))
+		}
+		return fmt.Sprintf("%d %s", distance.Amount, distance.Type)
+	default:
+		return fmt.Sprintf("%d %s", distance.Amount, distance.Type)
+	}
+}
+
+// singularDistanceUnit returns the singular form of a distance unit, as used in "1 foot" or "15-foot cone"
+func singularDistanceUnit(unit string) string {
+	switch unit {
+	case "feet":
+		return "foot"
+	case "miles":
+		return "mile"
+	default:
+		return unit
+	}
+}
+
+// formatSpellDuration formats a spell's duration, e.g. "Instantaneous", "Concentration, up to 1 minute",
+// "Up to 8 hours" or "Until dispelled or triggered"
+func formatSpellDuration(duration SpellDuration) string {
+	switch duration.Type {
+	case "instant":
+		return "Instantaneous"
+	case "special":
+		return "Special"
+	case "permanent":
+		var ends []string
+		for _, end := range duration.Ends {
+			if text, ok := spellDurationEnds[end]; ok {
+				ends = append(ends, text)
+			} else {
+				ends = append(ends, end)
+			}
+		}
+		if len(ends) == 0 && duration.Condition != "" {
+			ends = append(ends, "dispelled", duration.Condition)
+		}
+		if len(ends) == 0 {
+			return "Permanent"
+		}
+		return "Until " + strings.Join(ends, " or ")
+	case "timed", "concentration":
+		var length string
+		if amount, ok := duration.Duration["amount"].(float64); ok {
+			unit, _ := duration.Duration["type"].(string)
+			length = formatAmount(int(amount), unit)
+		}
+		upTo, _ := duration.Duration["upTo"].(bool)
+
+		switch {
+		case duration.Concentration || duration.Type == "concentration":
+			if length == "" {
+				return "Concentration"
+			}
+			return "Concentration, up to " + length
+		case upTo:
+			return "Up to " + length
+		default:
+			return length
+		}
+	default:
+		return duration.Type
+	}
+}
+
+// spellMaterial describes a spell's material component
+type spellMaterial struct {
+	text    string
+	cost    float64 // In copper pieces, zero when the component has no cost
+	consume string  // "consumed", "may be consumed" or empty
+}
+
+// getSpellMaterial returns the material component of a spell, which is either plain text or an object with
+// the text, the cost in copper pieces and whether the spell consumes it
+func getSpellMaterial(components SpellComponents) (spellMaterial, bool) {
+	var material spellMaterial
+	switch m := components.M.(type) {
+	case string:
+		material.text = m
+	case bool:
+		if !m {
+			return material, false
+		}
+	case map[string]interface{}:
+		material.text, _ = m["text"].(string)
+		material.cost, _ = m["cost"].(float64)
+		switch consume := m["consume"].(type) {
+		case bool:
+			if consume {
+				material.consume = "consumed"
+			}
+		case string:
+			if consume == "optional" {
+				material.consume = "may be consumed"
+			}
+		}
+	default:
+		return material, false
+	}
+	return material, true
+}
+
+// formatMaterialCost formats the cost of a material component, highlighted so it stands out, e.g. "==300 gp==, consumed"
+func formatMaterialCost(material spellMaterial) string {
+	var parts []string
+	if material.cost > 0 {
+		parts = append(parts, fmt.Sprintf("==%s==", formatCoins(material.cost)))
+	}
+	if material.consume != "" {
+		parts = append(parts, material.consume)
+	}
+	return strings.Join(parts, ", ")
+}
@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSpellTagNames(t *testing.T) {
+	tests := []struct {
+		codes    []string
+		names    map[string]string
+		expected string
+	}{
+		{[]string{"S", "ST"}, spellAreaNames, "Sphere, Single Target"},
+		{[]string{"R"}, spellAttackNames, "Ranged"},
+		{[]string{"SMN", "XYZ"}, spellMiscTagNames, "Summons Creature, XYZ"},
+	}
+
+	for _, tt := range tests {
+		if result := strings.Join(spellTagNames(tt.codes, tt.names), ", "); result != tt.expected {
+			t.Errorf("spellTagNames(%v) = %q, want %q", tt.codes, result, tt.expected)
+		}
+	}
+}
+
+func TestSpellToMarkdown_Tags(t *testing.T) {
+	spell := Spell{
+		Name:   "Detect Magic",
+		Source: "PHB",
+		Level:  1,
+		School: "D",
+		Meta:   map[string]interface{}{"ritual": true},
+		Duration: []SpellDuration{
+			{Type: "timed", Concentration: true, Duration: map[string]interface{}{"type": "minute", "amount": float64(10)}},
+		},
+		AreaTags:         []string{"S"},
+		SpellAttack:      []string{"R"},
+		ConditionInflict: []string{"blinded"},
+		DamageResist:     []string{"fire"},
+		AbilityCheck:     []string{"strength"},
+		MiscTags:         []string{"SGT"},
+	}
+
+	md, err := spellToMarkdown(spell)
+	if err != nil {
+		t.Fatalf("spellToMarkdown() error = %v", err)
+	}
+
+	expectedFrontmatter := "---\n" +
+		"level: 1\n" +
+		"school: Divination\n" +
+		"ritual: true\n" +
+		"concentration: true\n" +
+		"area:\n  - sphere\n" +
+		"attack:\n  - ranged\n" +
+		"damageResist:\n  - fire\n" +
+		"conditions:\n  - blinded\n" +
+		"abilityCheck:\n  - strength\n" +
+		"tags:\n  - requires sight\n" +
+		"---\n\n"
+	if !strings.HasPrefix(md, expectedFrontmatter) {
+		t.Errorf("spellToMarkdown() frontmatter = %q, want prefix %q", md, expectedFrontmatter)
+	}
+
+	expectedStrings := []string{
+		"*1st-level Divination (ritual)*",
+		"**Area:** Sphere",
+		"**Spell Attack:** Ranged",
+		"**Conditions:** [[Blinded]]",
+		"**Damage Resistance:** fire",
+		"**Ability Check:** strength",
+		"**Tags:** Requires Sight",
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(md, expected) {
+			t.Errorf("spellToMarkdown() missing %q, got %s", expected, md)
+		}
+	}
+}
+
+func TestFormatSpellTime(t *testing.T) {
+	tests := []struct {
+		time     SpellTime
+		expected string
+	}{
+		{SpellTime{Number: 1, Unit: "action"}, "1 action"},
+		{SpellTime{Number: 1, Unit: "bonus"}, "1 bonus action"},
+		{SpellTime{Number: 10, Unit: "minute"}, "10 minutes"},
+		{SpellTime{Number: 1, Unit: "reaction", Condition: "which you take when you fall"}, "1 reaction, which you take when you fall"},
+		{SpellTime{Unit: "special"}, "Special"},
+	}
+
+	for _, tt := range tests {
+		if result := formatSpellTime(tt.time); result != tt.expected {
+			t.Errorf("formatSpellTime(%v) = %q, want %q", tt.time, result, tt.expected)
+		}
+	}
+}
+
+func TestFormatSpellRange(t *testing.T) {
+	tests := []struct {
+		spellRange SpellRange
+		expected   string
+	}{
+		{SpellRange{Type: "point", Distance: SpellRangeDetail{Type: "feet", Amount: 150}}, "150 feet"},
+		{SpellRange{Type: "point", Distance: SpellRangeDetail{Type: "feet", Amount: 1}}, "1 foot"},
+		{SpellRange{Type: "point", Distance: SpellRangeDetail{Type: "miles", Amount: 1}}, "1 mile"},
+		{SpellRange{Type: "point", Distance: SpellRangeDetail{Type: "miles", Amount: 500}}, "500 miles"},
+		{SpellRange{Type: "point", Distance: SpellRangeDetail{Type: "touch"}}, "Touch"},
+		{SpellRange{Type: "point", Distance: SpellRangeDetail{Type: "unlimited"}}, "Unlimited"},
+		{SpellRange{Type: "special"}, "Special"},
+		{SpellRange{Type: "cone", Distance: SpellRangeDetail{Type: "feet", Amount: 15}}, "Self (15-foot cone)"},
+		{SpellRange{Type: "radius", Distance: SpellRangeDetail{Type: "feet", Amount: 30}}, "Self (30-foot radius)"},
+		{SpellRange{Type: "emanation", Distance: SpellRangeDetail{Type: "feet", Amount: 15}}, "Self (15-foot emanation)"},
+		{SpellRange{Type: "sphere", Distance: SpellRangeDetail{Type: "feet", Amount: 10}}, "Self (10-foot-radius sphere)"},
+		{SpellRange{Type: "hemisphere", Distance: SpellRangeDetail{Type: "feet", Amount: 10}}, "Self (10-foot-radius hemisphere)"},
+	}
+
+	for _, tt := range tests {
+		if result := formatSpellRange(tt.spellRange); result != tt.expected {
+			t.Errorf("formatSpellRange(%v) = %q, want %q", tt.spellRange, result, tt.expected)
+		}
+	}
+}
+
+func TestFormatSpellDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		duration SpellDuration
+		expected string
+	}{
+		{"Instantaneous", SpellDuration{Type: "instant"}, "Instantaneous"},
+		{"Singular", SpellDuration{Type: "timed", Duration: map[string]interface{}{"type": "minute", "amount": float64(1)}}, "1 minute"},
+		{"Plural", SpellDuration{Type: "timed", Duration: map[string]interface{}{"type": "hour", "amount": float64(8)}}, "8 hours"},
+		{"Up to", SpellDuration{Type: "timed", Duration: map[string]interface{}{"type": "hour", "amount": float64(8), "upTo": true}}, "Up to 8 hours"},
+		{"Concentration", SpellDuration{Type: "timed", Concentration: true, Duration: map[string]interface{}{"type": "minute", "amount": float64(1)}}, "Concentration, up to 1 minute"},
+		{"Until dispelled", SpellDuration{Type: "permanent", Ends: []string{"dispel"}}, "Until dispelled"},
+		{"Several ends", SpellDuration{Type: "permanent", Ends: []string{"dispel", "trigger"}}, "Until dispelled or triggered"},
+		{"Permanent", SpellDuration{Type: "permanent"}, "Permanent"},
+		{"Special", SpellDuration{Type: "special"}, "Special"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := formatSpellDuration(tt.duration); result != tt.expected {
+				t.Errorf("formatSpellDuration() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetSpellMaterial(t *testing.T) {
+	tests := []struct {
+		name         string
+		components   SpellComponents
+		expectedText string
+		expectedCost string
+	}{
+		{"No material", SpellComponents{V: true}, "", ""},
+		{"Plain text", SpellComponents{M: "a bit of fleece"}, "a bit of fleece", ""},
+		{"Cost", SpellComponents{M: map[string]interface{}{"text": "a pearl worth at least 100 gp", "cost": float64(10000)}}, "a pearl worth at least 100 gp", "==100 gp=="},
+		{"Consumed", SpellComponents{M: map[string]interface{}{"text": "diamonds", "cost": float64(30000), "consume": true}}, "diamonds", "==300 gp==, consumed"},
+		{"Optionally consumed", SpellComponents{M: map[string]interface{}{"text": "incense", "consume": "optional"}}, "incense", "may be consumed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			material, _ := getSpellMaterial(tt.components)
+			if material.text != tt.expectedText {
+				t.Errorf("getSpellMaterial() text = %q, want %q", material.text, tt.expectedText)
+			}
+			if cost := formatMaterialCost(material); cost != tt.expectedCost {
+				t.Errorf("formatMaterialCost() = %q, want %q", cost, tt.expectedCost)
+			}
+		})
+	}
+}
@@ -37,20 +37,31 @@ type Spell struct {
 	SavingThrow        []string               `json:"savingThrow,omitempty"`
 	MiscTags           []string               `json:"miscTags,omitempty"`
 	AreaTags           []string               `json:"areaTags,omitempty"`
+	ConditionInflict   []string               `json:"conditionInflict,omitempty"`
+	DamageResist       []string               `json:"damageResist,omitempty"`
+	SpellAttack        []string               `json:"spellAttack,omitempty"` // "M", "R" or "O"
+	AbilityCheck       []string               `json:"abilityCheck,omitempty"`
 	Classes            SpellClasses           `json:"classes,omitempty"`
+	Races              []SpellReference       `json:"races,omitempty"`
+	Backgrounds        []SpellReference       `json:"backgrounds,omitempty"`
+	Feats              []SpellReference       `json:"feats,omitempty"`
+	OptionalFeatures   []SpellReference       `json:"optionalfeatures,omitempty"`
 	Meta               map[string]interface{} `json:"meta,omitempty"`
 	ScalingLevelDice   interface{}            `json:"scalingLevelDice,omitempty"`
 	// Fields for handling variations in spell data
-	SRD         interface{} `json:"srd,omitempty"`
-	BasicRules  interface{} `json:"basicRules,omitempty"`
-	ReprintedAs []string    `json:"reprintedAs,omitempty"`
+	SRD         interface{}   `json:"srd,omitempty"`
+	BasicRules  interface{}   `json:"basicRules,omitempty"`
+	ReprintedAs []interface{} `json:"reprintedAs,omitempty"` // Can be "Fireball|XPHB" or {"uid": "Fireball|XPHB", "tag": "spell"}
+	NoteName    string        `json:"-"`                     // The note the spell is written to, when it differs from its name
 	// Additional fields can be added as needed
 }
 
 // SpellTime represents the casting time of a spell
 type SpellTime struct {
-	Number int    `json:"number"`
-	Unit   string `json:"unit"`
+	Number    int    `json:"number"`
+	Unit      string `json:"unit"`
+	Condition string `json:"condition,omitempty"` // The trigger of a reaction
+	Note      string `json:"note,omitempty"`
 }
 
 // SpellRange represents the range of a spell
@@ -79,12 +90,14 @@ type SpellDuration struct {
 	Duration      map[string]interface{} `json:"duration,omitempty"`
 	Condition     string                 `json:"condition,omitempty"`
 	Concentration bool                   `json:"concentration,omitempty"`
+	Ends          []string               `json:"ends,omitempty"` // How a permanent spell ends, e.g. "dispel" or "trigger"
 }
 
 // SpellClasses represents the classes that can use a spell
 type SpellClasses struct {
-	FromClassList []SpellClass `json:"fromClassList,omitempty"`
-	// Additional class-related fields can be added as needed
+	FromClassList        []SpellClass        `json:"fromClassList,omitempty"`
+	FromClassListVariant []SpellClassVariant `json:"fromClassListVariant,omitempty"` // Optional class features, e.g. from TCE
+	FromSubclass         []SpellSubclass     `json:"fromSubclass,omitempty"`
 }
 
 // SpellClass represents a class that can use a spell
@@ -93,6 +106,33 @@ type SpellClass struct {
 	Source string `json:"source"`
 }
 
+// SpellClassVariant represents a class that gains a spell through an optional class feature
+type SpellClassVariant struct {
+	Name            string `json:"name"`
+	Source          string `json:"source"`
+	DefinedInSource string `json:"definedInSource,omitempty"`
+}
+
+// SpellSubclass represents a subclass that adds a spell to its spell list
+type SpellSubclass struct {
+	Class    SpellClass        `json:"class"`
+	Subclass SpellSubclassInfo `json:"subclass"`
+}
+
+// SpellSubclassInfo identifies a subclass, e.g. "Light Domain" with the short name "Light"
+type SpellSubclassInfo struct {
+	Name        string `json:"name"`
+	ShortName   string `json:"shortName"`
+	Source      string `json:"source"`
+	SubSubclass string `json:"subSubclass,omitempty"` // e.g. the land of a Circle of the Land druid
+}
+
+// SpellReference identifies a race, background, feat or optional feature that grants a spell
+type SpellReference struct {
+	Name   string `json:"name"`
+	Source string `json:"source"`
+}
+
 // ScalingLevelDice represents the scaling damage dice for cantrips and other scaling spells
 type ScalingLevelDice struct {
 	Label   string            `json:"label,omitempty"`
@@ -100,7 +140,8 @@ type ScalingLevelDice struct {
 }
 
 // parseSpells parses the spell data from the specified directory and writes it to the output directory.
-func parseSpells(ctx context.Context, dataDirectory, outDirectory string) error {
+// The edition decides which printings of reprinted spells are written, and the filter which sources are.
+func parseSpells(ctx context.Context, dataDirectory, outDirectory, edition string, filter *sourceFilter) error {
 	var (
 		spellsPath = filepath.Join(dataDirectory, "spells")
 		indexPath  = filepath.Join(spellsPath, "index.json")
@@ -123,103 +164,96 @@ func parseSpells(ctx context.Context, dataDirectory, outDirectory string) error
 		return fmt.Errorf("failed to parse index file: %w", err)
 	}
 
-	// Process each spell file
-	for source, filename := range index {
-		if err := processSpellFile(ctx, spellsPath, outDir, source, filename); err != nil {
-			return fmt.Errorf("failed to process spell file %s: %w", filename, err)
-		}
-	}
-
-	return nil
-}
-
-// processSpellFile processes a single spell file and generates Markdown for each spell
-func processSpellFile(ctx context.Context, spellsPath, outDir, source, filename string) error {
-	// Read and parse the spell file
-	filePath := filepath.Join(spellsPath, filename)
-	fileData, err := os.ReadFile(filePath)
+	// Current data lists the classes, subclasses and other options that grant each spell in a separate lookup
+	sources, err := readSpellSourceLookup(dataDirectory)
 	if err != nil {
-		return fmt.Errorf("failed to read spell file: %w", err)
+		return err
 	}
 
-	var spellFile SpellFile
-	if err := json.Unmarshal(fileData, &spellFile); err != nil {
-		return fmt.Errorf("failed to parse spell file: %w", err)
+	// Read every spell file before writing any notes, since the reprints of spells in one book are in another
+	reprints := newReprintFilter(edition, filter)
+	var allSpells []Spell
+	for _, source := range sortedKeys(index) {
+		filename := index[source]
+		spells, err := readSpellFile(spellsPath, filename)
+		if err != nil {
+			return fmt.Errorf("failed to process spell file %s: %w", filename, err)
+		}
+		for _, spell := range spells {
+			reprints.add(spell.Source, spell.ReprintedAs)
+		}
+		allSpells = append(allSpells, spells...)
 	}
 
 	// Process each spell
-	for _, spell := range spellFile.Spell {
+	var written []Spell
+	for _, spell := range allSpells {
+		if !filter.keep(spell.Source) || !reprints.keep(spell.Name, spell.Source, spell.ReprintedAs) {
+			continue
+		}
+		spell.ReprintedAs = reprints.links(spell.ReprintedAs)
+		if entry, ok := sources.find(spell.Name, spell.Source); ok {
+			mergeSpellSources(&spell, entry)
+		}
+
 		mdContent, err := spellToMarkdown(spell)
 		if err != nil {
 			return fmt.Errorf("failed to convert spell to markdown: %w", err)
 		}
 
-		// Create a filename for the spell
-		safeSpellName := strings.ReplaceAll(spell.Name, "/", "-")
-		safeSpellName = strings.ReplaceAll(safeSpellName, "\\", "-")
-		safeSpellName = strings.ReplaceAll(safeSpellName, ":", "-")
-		safeSpellName = strings.ReplaceAll(safeSpellName, "*", "-")
-		safeSpellName = strings.ReplaceAll(safeSpellName, "?", "-")
-		safeSpellName = strings.ReplaceAll(safeSpellName, "\"", "-")
-		safeSpellName = strings.ReplaceAll(safeSpellName, "<", "-")
-		safeSpellName = strings.ReplaceAll(safeSpellName, ">", "-")
-		safeSpellName = strings.ReplaceAll(safeSpellName, "|", "-")
-
-		mdFilePath := filepath.Join(outDir, safeSpellName+".md")
-
-		// Write the markdown file
-		if err := os.WriteFile(mdFilePath, []byte(mdContent), 0644); err != nil {
-			return fmt.Errorf("failed to write markdown file: %w", err)
+		spell.NoteName = reprints.noteName(spell.Name, spell.Source, spell.ReprintedAs)
+		if err := writeNote(outDir, spell.NoteName, mdContent); err != nil {
+			return err
 		}
+		written = append(written, spell)
 	}
 
-	return nil
+	// Index notes listing the spells of each class and subclass
+	return writeSpellLists(outDirectory, written)
+}
+
+// readSpellFile reads and parses a single spell file
+func readSpellFile(spellsPath, filename string) ([]Spell, error) {
+	fileData, err := os.ReadFile(filepath.Join(spellsPath, filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spell file: %w", err)
+	}
+
+	var spellFile SpellFile
+	if err := json.Unmarshal(fileData, &spellFile); err != nil {
+		return nil, fmt.Errorf("failed to parse spell file: %w", err)
+	}
+
+	return spellFile.Spell, nil
 }
 
 // spellToMarkdown converts a spell to Markdown format
 func spellToMarkdown(spell Spell) (string, error) {
 	var md strings.Builder
 
+	// Frontmatter, so spells can be filtered by their level, school and tags
+	writeSpellFrontmatter(&md, spell)
+
 	// Title
 	md.WriteString(fmt.Sprintf("# %s\n\n", spell.Name))
+	writeReprintBanner(&md, "spell", spell.ReprintedAs)
 
-	// Basic info
-	md.WriteString(fmt.Sprintf("*%s %s*\n\n", getSpellLevel(spell.Level), getSchoolName(spell.School)))
+	// Basic info, e.g. "3rd-level Evocation" or "1st-level Divination (ritual)"
+	md.WriteString(fmt.Sprintf("*%s %s", getSpellLevel(spell.Level), getSchoolName(spell.School)))
+	if isRitualSpell(spell) {
+		md.WriteString(" (ritual)")
+	}
+	md.WriteString("*\n\n")
 
 	// Casting Time
-	md.WriteString("**Casting Time:** ")
-	for i, time := range spell.Time {
-		if i > 0 {
-			md.WriteString(", ")
-		}
-		md.WriteString(fmt.Sprintf("%d %s", time.Number, time.Unit))
+	var times []string
+	for _, time := range spell.Time {
+		times = append(times, formatSpellTime(time))
 	}
-	md.WriteString("\n\n")
+	md.WriteString(fmt.Sprintf("**Casting Time:** %s\n\n", strings.Join(times, ", ")))
 
 	// Range
-	md.WriteString("**Range:** ")
-	if spell.Range.Type == "point" {
-		if spell.Range.Distance.Type == "self" {
-			md.WriteString("Self")
-		} else if spell.Range.Distance.Type == "touch" {
-			md.WriteString("Touch")
-		} else if spell.Range.Distance.Type == "sight" {
-			md.WriteString("Sight")
-		} else {
-			md.WriteString(fmt.Sprintf("%d %s", spell.Range.Distance.Amount, spell.Range.Distance.Type))
-		}
-	} else if spell.Range.Type == "radius" {
-		md.WriteString(fmt.Sprintf("%d-%s radius", spell.Range.Distance.Amount, spell.Range.Distance.Type))
-	} else if spell.Range.Type == "cone" {
-		md.WriteString(fmt.Sprintf("%d-%s cone", spell.Range.Distance.Amount, spell.Range.Distance.Type))
-	} else if spell.Range.Type == "line" {
-		md.WriteString(fmt.Sprintf("%d-%s line", spell.Range.Distance.Amount, spell.Range.Distance.Type))
-	} else if spell.Range.Type == "cube" {
-		md.WriteString(fmt.Sprintf("%d-%s cube", spell.Range.Distance.Amount, spell.Range.Distance.Type))
-	} else {
-		md.WriteString(spell.Range.Type)
-	}
-	md.WriteString("\n\n")
+	md.WriteString(fmt.Sprintf("**Range:** %s\n\n", formatSpellRange(spell.Range)))
 
 	// Components
 	md.WriteString("**Components:** ")
@@ -230,15 +264,11 @@ func spellToMarkdown(spell Spell) (string, error) {
 	if spell.Components.S {
 		components = append(components, "S")
 	}
-	if spell.Components.M != nil {
+	material, hasMaterial := getSpellMaterial(spell.Components)
+	if hasMaterial {
 		mStr := "M"
-		switch m := spell.Components.M.(type) {
-		case string:
-			mStr = fmt.Sprintf("M (%s)", m)
-		case map[string]interface{}:
-			if text, ok := m["text"].(string); ok {
-				mStr = fmt.Sprintf("M (%s)", text)
-			}
+		if material.text != "" {
+			mStr = fmt.Sprintf("M (%s)", material.text)
 		}
 		components = append(components, mStr)
 	}
@@ -248,45 +278,17 @@ func spellToMarkdown(spell Spell) (string, error) {
 	md.WriteString(strings.Join(components, ", "))
 	md.WriteString("\n\n")
 
-	// Duration
-	md.WriteString("**Duration:** ")
-	for i, duration := range spell.Duration {
-		if i > 0 {
-			md.WriteString(", ")
-		}
-
-		if duration.Type == "instant" {
-			md.WriteString("Instantaneous")
-		} else if duration.Type == "timed" {
-			// Check if this is a concentration spell
-			if duration.Concentration {
-				md.WriteString("Concentration, up to ")
-			}
+	// Costly and consumed material components
+	if cost := formatMaterialCost(material); hasMaterial && cost != "" {
+		md.WriteString(fmt.Sprintf("**Material Cost:** %s\n\n", cost))
+	}
 
-			if duration.Duration != nil {
-				if amount, ok := duration.Duration["amount"].(float64); ok {
-					unit, _ := duration.Duration["type"].(string)
-					md.WriteString(fmt.Sprintf("%d %s", int(amount), unit))
-				}
-			}
-		} else if duration.Type == "permanent" {
-			md.WriteString("Until dispelled")
-			if duration.Condition != "" {
-				md.WriteString(fmt.Sprintf(" or %s", duration.Condition))
-			}
-		} else if duration.Type == "concentration" {
-			md.WriteString("Concentration")
-			if duration.Duration != nil {
-				if amount, ok := duration.Duration["amount"].(float64); ok {
-					unit, _ := duration.Duration["type"].(string)
-					md.WriteString(fmt.Sprintf(", up to %d %s", int(amount), unit))
-				}
-			}
-		} else {
-			md.WriteString(duration.Type)
-		}
+	// Duration
+	var durations []string
+	for _, duration := range spell.Duration {
+		durations = append(durations, formatSpellDuration(duration))
 	}
-	md.WriteString("\n\n")
+	md.WriteString(fmt.Sprintf("**Duration:** %s\n\n", strings.Join(durations, ", ")))
 
 	// Description
 	for _, entry := range spell.Entries {
@@ -442,46 +444,14 @@ func spellToMarkdown(spell Spell) (string, error) {
 
 	// Higher Levels
 	if len(spell.EntriesHigher) > 0 {
-		md.WriteString("**At Higher Levels:** ")
-		for _, entry := range spell.EntriesHigher {
-			if entryStr, ok := entry.(string); ok {
-				md.WriteString(processSpecialFormatting(entryStr))
-				md.WriteString("\n\n")
-			} else if entryMap, ok := entry.(map[string]interface{}); ok {
-				if entryType, ok := entryMap["type"].(string); ok && entryType == "entries" {
-					if entries, ok := entryMap["entries"].([]interface{}); ok {
-						for _, subEntry := range entries {
-							if subEntryStr, ok := subEntry.(string); ok {
-								md.WriteString(processSpecialFormatting(subEntryStr))
-								md.WriteString("\n\n")
-							}
-						}
-					}
-				}
-			}
-		}
+		writeSpellHigherLevels(&md, spell.EntriesHigher, "At Higher Levels")
 	} else if len(spell.EntriesHigherLevel) > 0 {
-		// Process entriesHigherLevel field
-		for _, entry := range spell.EntriesHigherLevel {
-			if entryMap, ok := entry.(map[string]interface{}); ok {
-				if entryType, ok := entryMap["type"].(string); ok && entryType == "entries" {
-					if name, ok := entryMap["name"].(string); ok {
-						md.WriteString(fmt.Sprintf("**%s:** ", name))
-					} else {
-						md.WriteString("**At Higher Levels:** ")
-					}
-
-					if entries, ok := entryMap["entries"].([]interface{}); ok {
-						for _, subEntry := range entries {
-							if subEntryStr, ok := subEntry.(string); ok {
-								md.WriteString(processSpecialFormatting(subEntryStr))
-								md.WriteString("\n\n")
-							}
-						}
-					}
-				}
-			}
+		// 2024 spells name these sections "Using a Higher-Level Spell Slot" and "Cantrip Upgrade"
+		defaultName := "At Higher Levels"
+		if spell.Level == 0 {
+			defaultName = "Cantrip Upgrade"
 		}
+		writeSpellHigherLevels(&md, spell.EntriesHigherLevel, defaultName)
 	}
 
 	// Damage Type
@@ -498,16 +468,45 @@ func spellToMarkdown(spell Spell) (string, error) {
 		md.WriteString("\n\n")
 	}
 
+	// Area, attack type, conditions and other tags
+	writeSpellTags(&md, spell)
+
 	// Classes
 	if spell.Classes.FromClassList != nil && len(spell.Classes.FromClassList) > 0 {
 		md.WriteString("**Classes:** ")
-		var classNames []string
-		for _, class := range spell.Classes.FromClassList {
-			classNames = append(classNames, class.Name)
-		}
-		md.WriteString(strings.Join(classNames, ", "))
+		md.WriteString(strings.Join(spellClassNames(spell.Classes.FromClassList), ", "))
 		md.WriteString("\n\n")
 	}
+	if len(spell.Classes.FromClassListVariant) > 0 {
+		var variantNames []string
+		for _, variant := range spell.Classes.FromClassListVariant {
+			name := variant.Name
+			if variant.DefinedInSource != "" {
+				name = fmt.Sprintf("%s (%s)", name, variant.DefinedInSource)
+			}
+			variantNames = append(variantNames, name)
+		}
+		md.WriteString(fmt.Sprintf("**Optional Classes:** %s\n\n", strings.Join(variantNames, ", ")))
+	}
+	if len(spell.Classes.FromSubclass) > 0 {
+		md.WriteString(fmt.Sprintf("**Subclasses:** %s\n\n", strings.Join(spellSubclassNames(spell.Classes.FromSubclass), ", ")))
+	}
+
+	// Races, backgrounds, feats and optional features that grant the spell
+	grantedBy := []struct {
+		label      string
+		references []SpellReference
+	}{
+		{"Races", spell.Races},
+		{"Backgrounds", spell.Backgrounds},
+		{"Feats", spell.Feats},
+		{"Optional Features", spell.OptionalFeatures},
+	}
+	for _, group := range grantedBy {
+		if len(group.references) > 0 {
+			md.WriteString(fmt.Sprintf("**%s:** %s\n\n", group.label, strings.Join(spellReferenceNames(group.references), ", ")))
+		}
+	}
 
 	// Source
 	md.WriteString(fmt.Sprintf("**Source:** %s", spell.Source))
@@ -550,16 +549,7 @@ func getSpellLevel(level int) string {
 		return "Cantrip"
 	}
 
-	switch level {
-	case 1:
-		return "1st-level"
-	case 2:
-		return "2nd-level"
-	case 3:
-		return "3rd-level"
-	default:
-		return fmt.Sprintf("%dth-level", level)
-	}
+	return getOrdinal(level) + "-level"
 }
 
 // getSchoolName returns the full name of a spell school
@@ -583,22 +573,11 @@ func getSchoolName(school string) string {
 
 // formatLevel formats a level number as a string (e.g., "1" -> "1st level")
 func formatLevel(level string) string {
-	switch level {
-	case "1":
-		return "1st level"
-	case "2":
-		return "2nd level"
-	case "3":
-		return "3rd level"
-	case "5":
-		return "5th level"
-	case "11":
-		return "11th level"
-	case "17":
-		return "17th level"
-	default:
-		return level + "th level"
+	n, err := strconv.Atoi(level)
+	if err != nil {
+		return level + " level"
 	}
+	return getOrdinal(n) + " level"
 }
 
 // processSpecialFormatting handles the special formatting in spell descriptions
@@ -611,8 +590,9 @@ func processSpecialFormatting(text string) string {
 	// Handle {@dice X} format - converts dice tags to plain text
 	text = processDiceTag(text)
 
-	// Handle {@scaledamage X} format - converts scaled damage tags to plain text
+	// Handle {@scaledamage X} and {@scaledice X} formats - converts scaled dice tags to plain text
 	text = processScaleDamageTag(text)
+	text = processScaleDiceTag(text)
 
 	// Handle {@spell X} format - converts spell references to plain text
 	text = processSpellTag(text)
@@ -623,10 +603,10 @@ func processSpecialFormatting(text string) string {
 	// Handle {@creature X} format - converts creature references to plain text
 	text = processCreatureTag(text)
 
-	// Handle {@condition X} format - converts condition references to plain text
+	// Handle {@condition X} format - converts condition references to links
 	text = processConditionTag(text)
 
-	// Handle {@hazard X} format - converts hazard references to plain text
+	// Handle {@hazard X} format - converts hazard references to links
 	text = processHazardTag(text)
 
 	// Handle {@atk X} format - converts attack tags to plain text
@@ -644,15 +624,33 @@ func processSpecialFormatting(text string) string {
 	// Handle {@recharge X} format - converts recharge tags to plain text
 	text = processRechargeTag(text)
 
-	// Handle {@action X} format - converts action tags to plain text
+	// Handle {@action X} format - converts action tags to links
 	text = processActionTag(text)
 
-	// Handle {@skill X} format - converts skill tags to plain text
+	// Handle {@skill X} format - converts skill tags to links
 	text = processSkillTag(text)
 
+	// Handle {@sense X} format - converts sense tags to links
+	text = processSenseTag(text)
+
+	// Handle {@language X} format - converts language tags to links
+	text = processLanguageTag(text)
+
+	// Handle {@variantrule X} format - converts variant rule tags to links
+	text = processVariantRuleTag(text)
+
+	// Handle {@deity X} format - converts deity tags to links
+	text = processDeityTag(text)
+
+	// Handle {@table X} format - converts table tags to links
+	text = processTableTag(text)
+
 	// Handle {@chance X} format - converts chance tags to plain text
 	text = processChanceTag(text)
 
+	// Handle any remaining tags, e.g. {@race elf|XPHB} or {@i italic text}
+	text = processRemainingTags(text)
+
 	return text
 }
 
@@ -795,35 +793,22 @@ func processCreatureTag(text string) string {
 	return text
 }
 
-// processConditionTag handles the {@condition X} format in spell descriptions
-// Example: {@condition poisoned} -> poisoned
+// processConditionTag handles the {@condition X}, {@disease X} and {@status X} formats in spell descriptions
+// Example: {@condition poisoned} -> [[poisoned]]
 func processConditionTag(text string) string {
-	// Simple regex-like replacement for {@condition X}
-	for {
-		start := strings.Index(text, "{@condition ")
-		if start == -1 {
-			break
-		}
-
-		end := strings.Index(text[start:], "}")
-		if end == -1 {
-			break
-		}
-		end += start
-
-		conditionText := text[start+12 : end]
-		text = text[:start] + conditionText + text[end+1:]
-	}
-
+	text = processLinkTag(text, "condition")
+	text = processLinkTag(text, "disease")
+	text = processLinkTag(text, "status")
 	return text
 }
 
-// processHazardTag handles the {@hazard X} format in spell descriptions
-// Example: {@hazard burning|XPHB} -> burning
-func processHazardTag(text string) string {
-	// Simple regex-like replacement for {@hazard X}
+// processLinkTag handles reference tags that point at a generated note, converting them to Obsidian links
+// Format can be {@tag name}, {@tag name|source} or {@tag name|source|display text}
+// Example: {@condition blinded|XPHB} -> [[blinded]]
+func processLinkTag(text, tag string) string {
+	prefix := "{@" + tag + " "
 	for {
-		start := strings.Index(text, "{@hazard ")
+		start := strings.Index(text, prefix)
 		if start == -1 {
 			break
 		}
@@ -834,11 +819,11 @@ func processHazardTag(text string) string {
 		}
 		end += start
 
-		// Extract the hazard name, handling the case where there might be a pipe character
-		// Format can be {@hazard name} or {@hazard name|source}
-		hazardText := text[start+9 : end]
-		parts := strings.Split(hazardText, "|")
-		displayText := parts[0]
+		parts := strings.Split(text[start+len(prefix):end], "|")
+		displayText := linkTo(parts[0])
+		if len(parts) > 2 && parts[2] != "" {
+			displayText = fmt.Sprintf("[[%s|%s]]", safeFileName(parts[0]), parts[2])
+		}
 
 		text = text[:start] + displayText + text[end+1:]
 	}
@@ -846,6 +831,14 @@ func processHazardTag(text string) string {
 	return text
 }
 
+// processHazardTag handles the {@hazard X} and {@trap X} formats in spell descriptions
+// Example: {@hazard burning|XPHB} -> [[burning]]
+func processHazardTag(text string) string {
+	text = processLinkTag(text, "hazard")
+	text = processLinkTag(text, "trap")
+	return text
+}
+
 // processAttackTag handles the {@atk X} format in monster descriptions
 // Example: {@atk mw} -> (melee weapon)
 func processAttackTag(text string) string {
@@ -973,9 +966,20 @@ func processRechargeTag(text string) string {
 // processScaleDamageTag handles the {@scaledamage X} format in spell descriptions
 // Example: {@scaledamage 8d6|3-9|1d6} -> 1d6
 func processScaleDamageTag(text string) string {
-	// Simple regex-like replacement for {@scaledamage X}
+	return processScaleTag(text, "scaledamage")
+}
+
+// processScaleDiceTag handles the {@scaledice X} format in spell descriptions
+// Example: {@scaledice 2d8|1-9|2d8} -> 2d8
+func processScaleDiceTag(text string) string {
+	return processScaleTag(text, "scaledice")
+}
+
+// processScaleTag replaces a scaled dice tag with the dice added per level
+func processScaleTag(text, tag string) string {
+	prefix := "{@" + tag + " "
 	for {
-		start := strings.Index(text, "{@scaledamage ")
+		start := strings.Index(text, prefix)
 		if start == -1 {
 			break
 		}
@@ -986,8 +990,8 @@ func processScaleDamageTag(text string) string {
 		}
 		end += start
 
-		// Extract the scaledamage text, which is in the format "baseDamage|levelRange|damagePerLevel"
-		scaleDamageText := text[start+13 : end]
+		// Extract the tag text, which is in the format "baseDamage|levelRange|damagePerLevel"
+		scaleDamageText := text[start+len(prefix) : end]
 		parts := strings.Split(scaleDamageText, "|")
 
 		// Use the last part (damagePerLevel) as the display text
@@ -1003,39 +1007,48 @@ func processScaleDamageTag(text string) string {
 }
 
 // processActionTag handles the {@action X} format in spell descriptions
-// Example: {@action Magic|XPHB} -> Magic
+// Example: {@action Magic|XPHB} -> [[Magic]]
 func processActionTag(text string) string {
-	// Simple regex-like replacement for {@action X}
-	for {
-		start := strings.Index(text, "{@action ")
-		if start == -1 {
-			break
-		}
+	return processLinkTag(text, "action")
+}
 
-		end := strings.Index(text[start:], "}")
-		if end == -1 {
-			break
-		}
-		end += start
+// processSkillTag handles the {@skill X} format in spell descriptions
+// Example: {@skill Athletics} -> [[Athletics]]
+func processSkillTag(text string) string {
+	return processLinkTag(text, "skill")
+}
 
-		// Extract the action name, handling the case where there might be a pipe character
-		// Format can be {@action name} or {@action name|source}
-		actionText := text[start+9 : end]
-		parts := strings.Split(actionText, "|")
-		displayText := parts[0]
+// processSenseTag handles the {@sense X} format in monster descriptions
+// Example: {@sense blindsight|XPHB} -> [[blindsight]]
+func processSenseTag(text string) string {
+	return processLinkTag(text, "sense")
+}
 
-		text = text[:start] + displayText + text[end+1:]
-	}
+// processLanguageTag handles the {@language X} format in descriptions
+// Example: {@language Abyssal} -> [[Abyssal]]
+func processLanguageTag(text string) string {
+	return processLinkTag(text, "language")
+}
 
-	return text
+// processVariantRuleTag handles the {@variantrule X} format in descriptions
+// Example: {@variantrule Hit Points|XPHB} -> [[Hit Points]]
+func processVariantRuleTag(text string) string {
+	return processLinkTag(text, "variantrule")
 }
 
-// processSkillTag handles the {@skill X} format in spell descriptions
-// Example: {@skill Athletics} -> Athletics
-func processSkillTag(text string) string {
-	// Simple regex-like replacement for {@skill X}
+// processTableTag handles the {@table X} format in descriptions
+// Example: {@table Trinkets|PHB} -> [[Trinkets]]
+func processTableTag(text string) string {
+	return processLinkTag(text, "table")
+}
+
+// processDeityTag handles the {@deity X} format in descriptions
+// Format can be {@deity name}, {@deity name|pantheon}, {@deity name|pantheon|source} or
+// {@deity name|pantheon|source|display text}. The pantheon defaults to the Forgotten Realms.
+// Example: {@deity Tyr|Norse} -> [[Tyr (Norse)|Tyr]]
+func processDeityTag(text string) string {
 	for {
-		start := strings.Index(text, "{@skill ")
+		start := strings.Index(text, "{@deity ")
 		if start == -1 {
 			break
 		}
@@ -1046,8 +1059,19 @@ func processSkillTag(text string) string {
 		}
 		end += start
 
-		skillText := text[start+8 : end]
-		text = text[:start] + skillText + text[end+1:]
+		parts := strings.Split(text[start+8:end], "|")
+		name := parts[0]
+		pantheon := defaultPantheon
+		if len(parts) > 1 && parts[1] != "" {
+			pantheon = parts[1]
+		}
+		displayText := name
+		if len(parts) > 3 && parts[3] != "" {
+			displayText = parts[3]
+		}
+
+		link := fmt.Sprintf("[[%s|%s]]", safeFileName(getDeityNoteName(name, pantheon)), displayText)
+		text = text[:start] + link + text[end+1:]
 	}
 
 	return text
@@ -1093,3 +1117,54 @@ func processChanceTag(text string) string {
 
 	return text
 }
+
+// processRemainingTags handles any tags not covered by a dedicated handler.
+// Formatting tags are converted to their Markdown equivalent, and reference tags are reduced to their
+// display text. Tags are processed innermost first so nested tags such as {@b {@dice 1d6}} work.
+// Example: {@race elf|XPHB} -> elf, {@i some text} -> *some text*
+func processRemainingTags(text string) string {
+	for {
+		start := strings.LastIndex(text, "{@")
+		if start == -1 {
+			break
+		}
+
+		end := strings.Index(text[start:], "}")
+		if end == -1 {
+			break
+		}
+		end += start
+
+		tag, content, _ := strings.Cut(text[start+2:end], " ")
+		parts := strings.Split(content, "|")
+
+		var displayText string
+		switch tag {
+		case "b", "bold":
+			displayText = "**" + content + "**"
+		case "i", "italic":
+			displayText = "*" + content + "*"
+		case "s", "strike":
+			displayText = "~~" + content + "~~"
+		case "u", "underline", "note", "sup", "sub", "kbd":
+			displayText = content
+		case "code":
+			displayText = "`" + content + "`"
+		case "highlight":
+			displayText = "==" + content + "=="
+		case "filter", "book", "adventure", "5etools", "link", "loader":
+			// These tags put the display text first, followed by a target
+			displayText = parts[0]
+		default:
+			// Reference tags use the form {@tag name|source|display text}
+			displayText = parts[0]
+			if len(parts) > 2 && parts[2] != "" {
+				displayText = parts[2]
+			}
+		}
+
+		text = text[:start] + displayText + text[end+1:]
+	}
+
+	return text
+}
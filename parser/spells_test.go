@@ -30,6 +30,26 @@ func TestGetSpellLevel(t *testing.T) {
 	}
 }
 
+func TestFormatLevel(t *testing.T) {
+	tests := []struct {
+		level    string
+		expected string
+	}{
+		{"1", "1st level"},
+		{"5", "5th level"},
+		{"11", "11th level"},
+		{"21", "21st level"},
+		{"22", "22nd level"},
+	}
+
+	for _, test := range tests {
+		result := formatLevel(test.level)
+		if result != test.expected {
+			t.Errorf("formatLevel(%s) = %s; want %s", test.level, result, test.expected)
+		}
+	}
+}
+
 func TestGetSchoolName(t *testing.T) {
 	tests := []struct {
 		school   string
@@ -224,7 +244,7 @@ func TestParseSpells_WithMockData(t *testing.T) {
 	}
 
 	// Parse the spells
-	if err := parseSpells(context.Background(), dataDir, outDir); err != nil {
+	if err := parseSpells(context.Background(), dataDir, outDir, "", nil); err != nil {
 		t.Fatalf("parseSpells() error = %v", err)
 	}
 
@@ -346,7 +366,7 @@ func TestSpellToMarkdown_EdgeCases(t *testing.T) {
 				},
 			},
 			expected: []string{
-				"**Duration:** Concentration, up to 10 minute",
+				"**Duration:** Concentration, up to 10 minutes",
 			},
 		},
 		{
@@ -370,7 +390,7 @@ func TestSpellToMarkdown_EdgeCases(t *testing.T) {
 					S: true,
 					M: map[string]interface{}{
 						"text":    "a diamond worth at least 300 gp, which the spell consumes",
-						"cost":    float64(300),
+						"cost":    float64(30000), // Costs are in copper pieces
 						"consume": true,
 					},
 					R: true,
@@ -384,6 +404,9 @@ func TestSpellToMarkdown_EdgeCases(t *testing.T) {
 			},
 			expected: []string{
 				"**Components:** V, S, M (a diamond worth at least 300 gp, which the spell consumes), R",
+				"**Material Cost:** ==300 gp==, consumed",
+				"materialCost: 300\n",
+				"materialConsumed: true\n",
 			},
 		},
 		{
@@ -648,8 +671,8 @@ func TestControlWaterSpell(t *testing.T) {
 		"**Casting Time:** 1 action",
 		"**Range:** 300 feet",
 		"**Components:** V, S, M (a mixture of water and dust)",
-		"**Duration:** Concentration, up to 10 minute",
-		"Until the spell ends, you control any water inside an area you choose that is a Cube up to 100 feet on a side, using one of the following effects. As a Magic action on your later turns, you can repeat the same effect or choose a different one.",
+		"**Duration:** Concentration, up to 10 minutes",
+		"Until the spell ends, you control any water inside an area you choose that is a Cube up to 100 feet on a side, using one of the following effects. As a [[Magic]] action on your later turns, you can repeat the same effect or choose a different one.",
 		"**Flood**",
 		"You cause the water level of all standing water in the area to rise by as much as 20 feet.",
 		"**Part Water**",
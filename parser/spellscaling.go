@@ -0,0 +1,133 @@
+package parser
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// scaleTagPattern matches the {@scaledamage} and {@scaledice} tags, e.g. "{@scaledamage 8d6|3-9|1d6}"
+var scaleTagPattern = regexp.MustCompile(`\{@scale(?:damage|dice) ([^}]+)\}`)
+
+// dicePattern matches a plain dice expression such as "8d6"
+var dicePattern = regexp.MustCompile(`^(\d+)d(\d+)$`)
+
+// writeSpellHigherLevels writes the sections describing how a spell improves, such as "At Higher Levels",
+// "Using a Higher-Level Spell Slot" or "Cantrip Upgrade". Unnamed sections use the default name, and each
+// section is followed by the progression of any scaled dice it mentions.
+func writeSpellHigherLevels(md *strings.Builder, entries []interface{}, defaultName string) {
+	for _, entry := range entries {
+		name := defaultName
+		content := []interface{}{entry}
+		if entryMap, ok := entry.(map[string]interface{}); ok && entryMap["type"] == "entries" {
+			if entryName, ok := entryMap["name"].(string); ok && entryName != "" {
+				name = entryName
+			}
+			content, _ = entryMap["entries"].([]interface{})
+		}
+
+		// The first paragraph follows the section name, and anything else, such as lists or tables, follows it
+		md.WriteString(fmt.Sprintf("**%s:** ", name))
+		if len(content) > 0 {
+			if text, ok := content[0].(string); ok {
+				md.WriteString(processSpecialFormatting(text))
+				md.WriteString("\n\n")
+				content = content[1:]
+			} else {
+				md.WriteString("\n\n")
+			}
+		} else {
+			md.WriteString("\n\n")
+		}
+		entriesToMarkdown(md, content, 3)
+
+		for _, match := range scaleTagPattern.FindAllStringSubmatch(entryText(entry), -1) {
+			progression := scaleProgression(match[1])
+			if len(progression) == 0 {
+				continue
+			}
+			md.WriteString("**Scaling by Spell Slot:**\n")
+			for _, step := range progression {
+				md.WriteString(fmt.Sprintf("- %s\n", step))
+			}
+			md.WriteString("\n")
+		}
+	}
+}
+
+// entryText returns all of the text in an entry and its nested entries, for finding tags
+func entryText(entry interface{}) string {
+	switch e := entry.(type) {
+	case string:
+		return e
+	case []interface{}:
+		var parts []string
+		for _, item := range e {
+			parts = append(parts, entryText(item))
+		}
+		return strings.Join(parts, "\n")
+	case map[string]interface{}:
+		var parts []string
+		for _, key := range []string{"entries", "items", "entry"} {
+			if value, ok := e[key]; ok {
+				parts = append(parts, entryText(value))
+			}
+		}
+		return strings.Join(parts, "\n")
+	}
+	return ""
+}
+
+// scaleProgression returns the dice at each level of a scaled dice tag such as "8d6|3-9|1d6", e.g.
+// "3rd level: 8d6", "4th level: 9d6" and so on. Each listed level adds one step, so "1d8|2,4,6|1d8" gives
+// 1d8, 2d8 and 3d8. It returns nothing when the dice can't be added up.
+func scaleProgression(tag string) []string {
+	parts := strings.Split(tag, "|")
+	if len(parts) < 3 {
+		return nil
+	}
+
+	base := dicePattern.FindStringSubmatch(strings.TrimSpace(parts[0]))
+	step := dicePattern.FindStringSubmatch(strings.TrimSpace(parts[2]))
+	if base == nil || step == nil || base[2] != step[2] {
+		return nil
+	}
+	baseCount, _ := strconv.Atoi(base[1])
+	stepCount, _ := strconv.Atoi(step[1])
+
+	levels := parseLevelRange(parts[1])
+	if len(levels) < 2 {
+		return nil
+	}
+
+	var progression []string
+	for i, level := range levels {
+		count := baseCount + i*stepCount
+		progression = append(progression, fmt.Sprintf("%s: %dd%s", formatLevel(strconv.Itoa(level)), count, base[2]))
+	}
+	return progression
+}
+
+// parseLevelRange parses a level range such as "3-9" or "1,3,5" into its levels
+func parseLevelRange(levelRange string) []int {
+	var levels []int
+	for _, part := range strings.Split(levelRange, ",") {
+		part = strings.TrimSpace(part)
+		if from, to, ok := strings.Cut(part, "-"); ok {
+			start, err1 := strconv.Atoi(from)
+			end, err2 := strconv.Atoi(to)
+			if err1 != nil || err2 != nil {
+				return nil
+			}
+			for level := start; level <= end; level++ {
+				levels = append(levels, level)
+			}
+		} else if level, err := strconv.Atoi(part); err == nil {
+			levels = append(levels, level)
+		} else {
+			return nil
+		}
+	}
+	return levels
+}
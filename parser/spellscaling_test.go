@@ -0,0 +1,88 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScaleProgression(t *testing.T) {
+	tests := []struct {
+		tag      string
+		expected []string
+	}{
+		{"8d6|3-9|1d6", []string{"3rd level: 8d6", "4th level: 9d6", "5th level: 10d6", "6th level: 11d6", "7th level: 12d6", "8th level: 13d6", "9th level: 14d6"}},
+		{"2d8|1,3,5|2d8", []string{"1st level: 2d8", "3rd level: 4d8", "5th level: 6d8"}},
+		{"1d8|2,3,5,7|1d8", []string{"2nd level: 1d8", "3rd level: 2d8", "5th level: 3d8", "7th level: 4d8"}}, // One step per listed level
+		{"1d6+3|1-9|1d6", nil}, // Modifiers can't be added up
+		{"1d8|1-9|1d6", nil},   // Nor can different dice
+	}
+
+	for _, tt := range tests {
+		result := scaleProgression(tt.tag)
+		if strings.Join(result, ", ") != strings.Join(tt.expected, ", ") {
+			t.Errorf("scaleProgression(%q) = %v, want %v", tt.tag, result, tt.expected)
+		}
+	}
+}
+
+func TestWriteSpellHigherLevels(t *testing.T) {
+	tests := []struct {
+		name        string
+		entries     []interface{}
+		defaultName string
+		expected    string
+	}{
+		{
+			name: "2024 higher-level spell slot",
+			entries: []interface{}{
+				map[string]interface{}{
+					"type":    "entries",
+					"name":    "Using a Higher-Level Spell Slot",
+					"entries": []interface{}{"The damage increases by {@scaledamage 8d6|3-5|1d6} for each spell slot level above 3."},
+				},
+			},
+			defaultName: "At Higher Levels",
+			expected: "**Using a Higher-Level Spell Slot:** The damage increases by 1d6 for each spell slot level above 3.\n\n" +
+				"**Scaling by Spell Slot:**\n" +
+				"- 3rd level: 8d6\n" +
+				"- 4th level: 9d6\n" +
+				"- 5th level: 10d6\n\n",
+		},
+		{
+			name: "Cantrip upgrade with a list",
+			entries: []interface{}{
+				map[string]interface{}{
+					"type": "entries",
+					"entries": []interface{}{
+						"The damage increases when you reach these levels:",
+						map[string]interface{}{"type": "list", "items": []interface{}{"Level 5: 2d10", "Level 11: 3d10"}},
+					},
+				},
+			},
+			defaultName: "Cantrip Upgrade",
+			expected: "**Cantrip Upgrade:** The damage increases when you reach these levels:\n\n" +
+				"- Level 5: 2d10\n" +
+				"- Level 11: 3d10\n\n",
+		},
+		{
+			name:        "Plain string",
+			entries:     []interface{}{"The healing increases by {@scaledice 2d8|1-3|2d8} for each slot level above 1st."},
+			defaultName: "At Higher Levels",
+			expected: "**At Higher Levels:** The healing increases by 2d8 for each slot level above 1st.\n\n" +
+				"**Scaling by Spell Slot:**\n" +
+				"- 1st level: 2d8\n" +
+				"- 2nd level: 4d8\n" +
+				"- 3rd level: 6d8\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var md strings.Builder
+			writeSpellHigherLevels(&md, tt.entries, tt.defaultName)
+			if result := md.String(); result != tt.expected {
+				t.Errorf("writeSpellHigherLevels() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
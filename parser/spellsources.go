@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// SpellSourceLookup represents the structure of the generated/gendata-spell-source-lookup.json file, which maps
+// the lower case source and name of each spell to everything that grants it
+type SpellSourceLookup map[string]map[string]SpellSourceEntry
+
+// SpellSourceEntry lists the classes, subclasses and other options that grant a spell. Each level of nesting is
+// keyed by a source and then a name, e.g. "class": {"PHB": {"Wizard": true}}.
+type SpellSourceEntry struct {
+	Class           map[string]map[string]interface{}                       `json:"class,omitempty"`
+	ClassVariant    map[string]map[string]interface{}                       `json:"classVariant,omitempty"` // {"definedInSources": ["TCE"]}
+	Subclass        map[string]map[string]map[string]map[string]interface{} `json:"subclass,omitempty"`     // Class source, class, subclass source, subclass
+	Race            map[string]map[string]interface{}                       `json:"race,omitempty"`
+	Background      map[string]map[string]interface{}                       `json:"background,omitempty"`
+	Feat            map[string]map[string]interface{}                       `json:"feat,omitempty"`
+	OptionalFeature map[string]map[string]interface{}                       `json:"optionalfeature,omitempty"`
+}
+
+// readSpellSourceLookup reads the spell source lookup. Older data lists classes on the spells themselves,
+// so a missing file is not an error.
+func readSpellSourceLookup(dataDirectory string) (SpellSourceLookup, error) {
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "generated", "gendata-spell-source-lookup.json"))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read spell source lookup: %w", err)
+	}
+
+	var lookup SpellSourceLookup
+	if err := json.Unmarshal(fileData, &lookup); err != nil {
+		return nil, fmt.Errorf("failed to parse spell source lookup: %w", err)
+	}
+
+	return lookup, nil
+}
+
+// find returns the lookup entry for the given spell name and source
+func (l SpellSourceLookup) find(name, source string) (SpellSourceEntry, bool) {
+	entry, ok := l[strings.ToLower(source)][strings.ToLower(name)]
+	return entry, ok
+}
+
+// mergeSpellSources adds the classes, subclasses and other options from a lookup entry to a spell,
+// skipping any the spell already lists
+func mergeSpellSources(spell *Spell, entry SpellSourceEntry) {
+	classes := &spell.Classes
+	for _, ref := range sortedSourceNames(entry.Class) {
+		if !containsSpellClass(classes.FromClassList, ref.Name, ref.Source) {
+			classes.FromClassList = append(classes.FromClassList, SpellClass{Name: ref.Name, Source: ref.Source})
+		}
+	}
+
+	for _, ref := range sortedSourceNames(entry.ClassVariant) {
+		variant := SpellClassVariant{Name: ref.Name, Source: ref.Source}
+		if value, ok := entry.ClassVariant[ref.Source][ref.Name].(map[string]interface{}); ok {
+			if sources, ok := value["definedInSources"].([]interface{}); ok && len(sources) > 0 {
+				variant.DefinedInSource, _ = sources[0].(string)
+			}
+		}
+		exists := false
+		for _, existing := range classes.FromClassListVariant {
+			if strings.EqualFold(existing.Name, variant.Name) && strings.EqualFold(existing.Source, variant.Source) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			classes.FromClassListVariant = append(classes.FromClassListVariant, variant)
+		}
+	}
+
+	for _, classRef := range sortedSourceNames(entry.Subclass) {
+		subclasses := entry.Subclass[classRef.Source][classRef.Name]
+		for _, subclassSource := range sortedKeys(subclasses) {
+			for _, shortName := range sortedKeys(subclasses[subclassSource]) {
+				subclass := SpellSubclass{
+					Class:    SpellClass{Name: classRef.Name, Source: classRef.Source},
+					Subclass: SpellSubclassInfo{Name: shortName, ShortName: shortName, Source: subclassSource},
+				}
+				if value, ok := subclasses[subclassSource][shortName].(map[string]interface{}); ok {
+					if name, ok := value["name"].(string); ok && name != "" {
+						subclass.Subclass.Name = name
+					}
+					if subSubclasses, ok := value["subSubclasses"].([]interface{}); ok && len(subSubclasses) > 0 {
+						subclass.Subclass.SubSubclass, _ = subSubclasses[0].(string)
+					}
+				}
+				exists := false
+				for _, existing := range classes.FromSubclass {
+					if strings.EqualFold(existing.Class.Name, subclass.Class.Name) &&
+						strings.EqualFold(existing.Subclass.ShortName, subclass.Subclass.ShortName) &&
+						strings.EqualFold(existing.Subclass.Source, subclass.Subclass.Source) {
+						exists = true
+						break
+					}
+				}
+				if !exists {
+					classes.FromSubclass = append(classes.FromSubclass, subclass)
+				}
+			}
+		}
+	}
+
+	spell.Races = mergeSpellReferences(spell.Races, entry.Race)
+	spell.Backgrounds = mergeSpellReferences(spell.Backgrounds, entry.Background)
+	spell.Feats = mergeSpellReferences(spell.Feats, entry.Feat)
+	spell.OptionalFeatures = mergeSpellReferences(spell.OptionalFeatures, entry.OptionalFeature)
+}
+
+// mergeSpellReferences adds the references in a source -> name lookup to a list, skipping any already listed
+func mergeSpellReferences(references []SpellReference, lookup map[string]map[string]interface{}) []SpellReference {
+	for _, ref := range sortedSourceNames(lookup) {
+		exists := false
+		for _, existing := range references {
+			if strings.EqualFold(existing.Name, ref.Name) && strings.EqualFold(existing.Source, ref.Source) {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			references = append(references, ref)
+		}
+	}
+	return references
+}
+
+// containsSpellClass reports whether a class list contains the given class
+func containsSpellClass(classes []SpellClass, name, source string) bool {
+	for _, class := range classes {
+		if strings.EqualFold(class.Name, name) && strings.EqualFold(class.Source, source) {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedSourceNames flattens a source -> name lookup into references, sorted by source and then name
+func sortedSourceNames[T any](lookup map[string]map[string]T) []SpellReference {
+	var references []SpellReference
+	for _, source := range sortedKeys(lookup) {
+		for _, name := range sortedKeys(lookup[source]) {
+			references = append(references, SpellReference{Name: name, Source: source})
+		}
+	}
+	return references
+}
+
+// sortedKeys returns the keys of a map in sorted order, so the output doesn't change between runs
+func sortedKeys[T any](m map[string]T) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// spellClassNames returns the names of the classes that have a spell on their spell list, without duplicates
+// from the same class being printed in several books
+func spellClassNames(classes []SpellClass) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, class := range classes {
+		key := strings.ToLower(class.Name)
+		if !seen[key] {
+			seen[key] = true
+			names = append(names, class.Name)
+		}
+	}
+	return names
+}
+
+// spellSubclassNames returns the subclasses that add a spell to their list, e.g. "Cleric (Light Domain)"
+func spellSubclassNames(subclasses []SpellSubclass) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, subclass := range subclasses {
+		name := subclass.Subclass.Name
+		if subclass.Subclass.SubSubclass != "" {
+			name += ", " + subclass.Subclass.SubSubclass
+		}
+		name = fmt.Sprintf("%s (%s)", subclass.Class.Name, name)
+		if !seen[strings.ToLower(name)] {
+			seen[strings.ToLower(name)] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// spellReferenceNames returns the names of a list of references without duplicates
+func spellReferenceNames(references []SpellReference) []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, ref := range references {
+		key := strings.ToLower(ref.Name)
+		if !seen[key] {
+			seen[key] = true
+			names = append(names, ref.Name)
+		}
+	}
+	return names
+}
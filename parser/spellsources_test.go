@@ -0,0 +1,153 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func testSpellSourceEntry() SpellSourceEntry {
+	return SpellSourceEntry{
+		Class: map[string]map[string]interface{}{
+			"PHB":  {"Wizard": true, "Sorcerer": true},
+			"XPHB": {"Wizard": true},
+		},
+		ClassVariant: map[string]map[string]interface{}{
+			"PHB": {"Cleric": map[string]interface{}{"definedInSources": []interface{}{"TCE"}}},
+		},
+		Subclass: map[string]map[string]map[string]map[string]interface{}{
+			"PHB": {"Cleric": {"PHB": {"Light": map[string]interface{}{"name": "Light Domain"}}}},
+		},
+		Race: map[string]map[string]interface{}{
+			"XPHB": {"Tiefling (Infernal Legacy)": true},
+		},
+		Feat: map[string]map[string]interface{}{
+			"PHB": {"Magic Initiate": true},
+		},
+	}
+}
+
+func TestMergeSpellSources(t *testing.T) {
+	spell := Spell{
+		Name:    "Fireball",
+		Source:  "PHB",
+		Classes: SpellClasses{FromClassList: []SpellClass{{Name: "Wizard", Source: "PHB"}}},
+	}
+	mergeSpellSources(&spell, testSpellSourceEntry())
+
+	// The class already on the spell isn't repeated, and the rest are added in order
+	expectedClasses := []SpellClass{{"Wizard", "PHB"}, {"Sorcerer", "PHB"}, {"Wizard", "XPHB"}}
+	if len(spell.Classes.FromClassList) != len(expectedClasses) {
+		t.Fatalf("mergeSpellSources() classes = %v, want %v", spell.Classes.FromClassList, expectedClasses)
+	}
+	for i, class := range spell.Classes.FromClassList {
+		if class != expectedClasses[i] {
+			t.Errorf("mergeSpellSources() class %d = %v, want %v", i, class, expectedClasses[i])
+		}
+	}
+
+	md, err := spellToMarkdown(spell)
+	if err != nil {
+		t.Fatalf("spellToMarkdown() error = %v", err)
+	}
+
+	expectedStrings := []string{
+		"**Classes:** Wizard, Sorcerer\n\n",
+		"**Optional Classes:** Cleric (TCE)\n\n",
+		"**Subclasses:** Cleric (Light Domain)\n\n",
+		"**Races:** Tiefling (Infernal Legacy)\n\n",
+		"**Feats:** Magic Initiate\n\n",
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(md, expected) {
+			t.Errorf("spellToMarkdown() missing %q, got %s", expected, md)
+		}
+	}
+}
+
+func TestSpellSubclassNames(t *testing.T) {
+	tests := []struct {
+		name       string
+		subclasses []SpellSubclass
+		expected   string
+	}{
+		{
+			name: "Subclass",
+			subclasses: []SpellSubclass{
+				{Class: SpellClass{Name: "Cleric"}, Subclass: SpellSubclassInfo{Name: "Light Domain"}},
+			},
+			expected: "Cleric (Light Domain)",
+		},
+		{
+			name: "Sub-subclass",
+			subclasses: []SpellSubclass{
+				{Class: SpellClass{Name: "Druid"}, Subclass: SpellSubclassInfo{Name: "Circle of the Land", SubSubclass: "Arctic"}},
+			},
+			expected: "Druid (Circle of the Land, Arctic)",
+		},
+		{
+			name: "Reprinted subclass",
+			subclasses: []SpellSubclass{
+				{Class: SpellClass{Name: "Cleric", Source: "PHB"}, Subclass: SpellSubclassInfo{Name: "Light Domain", Source: "PHB"}},
+				{Class: SpellClass{Name: "Cleric", Source: "XPHB"}, Subclass: SpellSubclassInfo{Name: "Light Domain", Source: "XPHB"}},
+			},
+			expected: "Cleric (Light Domain)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := strings.Join(spellSubclassNames(tt.subclasses), ", "); result != tt.expected {
+				t.Errorf("spellSubclassNames() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParseSpells_WithSourceLookup(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "spell-sources-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	for _, dir := range []string{"spells", "generated"} {
+		if err := os.MkdirAll(filepath.Join(dataDir, dir), 0755); err != nil {
+			t.Fatalf("Failed to create %s dir: %v", dir, err)
+		}
+	}
+
+	files := map[string]interface{}{
+		filepath.Join("spells", "index.json"):      map[string]string{"PHB": "spells-phb.json"},
+		filepath.Join("spells", "spells-phb.json"): SpellFile{Spell: []Spell{{Name: "Fireball", Source: "PHB", Level: 3, School: "V"}}},
+		filepath.Join("generated", "gendata-spell-source-lookup.json"): SpellSourceLookup{
+			"phb": {"fireball": testSpellSourceEntry()},
+		},
+	}
+	for name, content := range files {
+		data, err := json.Marshal(content)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s: %v", name, err)
+		}
+		if err := os.WriteFile(filepath.Join(dataDir, name), data, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", name, err)
+		}
+	}
+
+	if err := parseSpells(context.Background(), dataDir, outDir, "", nil); err != nil {
+		t.Fatalf("parseSpells() error = %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(outDir, "spells", "Fireball.md"))
+	if err != nil {
+		t.Fatalf("Failed to read Fireball.md: %v", err)
+	}
+	if !strings.Contains(string(content), "**Classes:** Sorcerer, Wizard") {
+		t.Errorf("Fireball.md missing classes from the lookup, got %s", content)
+	}
+}
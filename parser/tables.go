@@ -0,0 +1,173 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// TableFile represents the structure of the tables.json file
+type TableFile struct {
+	Table      []RollTable  `json:"table"`
+	TableGroup []TableGroup `json:"tableGroup"`
+}
+
+// RollTable represents a single standalone table such as a loot or wild magic table
+type RollTable struct {
+	Name      string        `json:"name"`
+	Source    string        `json:"source"`
+	Page      int           `json:"page,omitempty"`
+	Caption   string        `json:"caption,omitempty"`
+	ColLabels []interface{} `json:"colLabels,omitempty"`
+	Rows      []interface{} `json:"rows,omitempty"`
+	Footnotes []interface{} `json:"footnotes,omitempty"`
+	Intro     []interface{} `json:"intro,omitempty"`
+	Outro     []interface{} `json:"outro,omitempty"`
+}
+
+// TableGroup represents a set of related tables that are written to a single note
+type TableGroup struct {
+	Name   string      `json:"name"`
+	Source string      `json:"source"`
+	Page   int         `json:"page,omitempty"`
+	Tables []RollTable `json:"tables"`
+}
+
+// diceLabelPattern matches column labels that name a die to roll, e.g. "d100" or "1d6"
+var diceLabelPattern = regexp.MustCompile(`^\d*d\d+$`)
+
+// parseTables parses the table data from the specified directory and writes it to the output directory.
+func parseTables(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Create output directory if it doesn't exist
+	outDir := filepath.Join(outDirectory, "tables")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Read and parse the table file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "tables.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read table file: %w", err)
+	}
+
+	var tableFile TableFile
+	if err := json.Unmarshal(fileData, &tableFile); err != nil {
+		return fmt.Errorf("failed to parse table file: %w", err)
+	}
+
+	// Process each table
+	for _, table := range tableFile.Table {
+		mdContent, err := rollTableToMarkdown(table)
+		if err != nil {
+			return fmt.Errorf("failed to convert table to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, table.Name, mdContent); err != nil {
+			return err
+		}
+	}
+
+	// Process each table group
+	for _, group := range tableFile.TableGroup {
+		mdContent, err := tableGroupToMarkdown(group)
+		if err != nil {
+			return fmt.Errorf("failed to convert table group to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, group.Name, mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rollTableToMarkdown converts a standalone table to Markdown format
+func rollTableToMarkdown(table RollTable) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", table.Name))
+	md.WriteString("*Table*\n\n")
+
+	writeRollTable(&md, table, table.Name, "table", 2)
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", table.Source))
+	if table.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", table.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// tableGroupToMarkdown converts a group of related tables to Markdown format, with a section per table
+func tableGroupToMarkdown(group TableGroup) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", group.Name))
+	md.WriteString("*Table Group*\n\n")
+
+	for i, table := range group.Tables {
+		name := table.Name
+		if name == "" {
+			name = table.Caption
+		}
+		if name != "" {
+			md.WriteString(fmt.Sprintf("## %s\n\n", processSpecialFormatting(name)))
+		}
+		writeRollTable(&md, table, group.Name, fmt.Sprintf("table-%d", i+1), 3)
+	}
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", group.Source))
+	if group.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", group.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// writeRollTable writes a table with its intro, footnotes and outro. Tables whose first column is a die
+// roll get a block ID and an Obsidian Dice Roller button, so they can be rolled on from the note.
+func writeRollTable(md *strings.Builder, table RollTable, noteName, blockID string, depth int) {
+	entriesToMarkdown(md, table.Intro, depth)
+
+	// The caption is only worth repeating when it differs from the note title
+	caption := table.Caption
+	if caption == table.Name {
+		caption = ""
+	}
+	tableToMarkdown(md, map[string]interface{}{
+		"caption":   caption,
+		"colLabels": table.ColLabels,
+		"rows":      table.Rows,
+	})
+
+	if isRollable(table) {
+		md.WriteString(fmt.Sprintf("^%s\n\n", blockID))
+		md.WriteString(fmt.Sprintf("**Roll:** `dice: [[%s^%s]]`\n\n", safeFileName(noteName), blockID))
+	}
+
+	for _, footnote := range table.Footnotes {
+		md.WriteString(listItemToMarkdown(footnote) + "\n\n")
+	}
+
+	entriesToMarkdown(md, table.Outro, depth)
+}
+
+// isRollable reports whether the first column of a table is a die roll, e.g. "d100"
+func isRollable(table RollTable) bool {
+	if len(table.ColLabels) == 0 || len(table.Rows) == 0 {
+		return false
+	}
+	label := strings.ToLower(strings.TrimSpace(listItemToMarkdown(table.ColLabels[0])))
+	return diceLabelPattern.MatchString(label)
+}
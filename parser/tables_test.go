@@ -0,0 +1,161 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRollTableToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		table    RollTable
+		expected string
+	}{
+		{
+			name: "Rollable table",
+			table: RollTable{
+				Name:      "Wild Magic Surge",
+				Source:    "PHB",
+				Page:      104,
+				Caption:   "Wild Magic Surge",
+				ColLabels: []interface{}{"{@dice d100}", "Effect"},
+				Rows: []interface{}{
+					[]interface{}{
+						map[string]interface{}{"type": "cell", "roll": map[string]interface{}{"min": float64(1), "max": float64(2), "pad": true}},
+						"Roll on this table at the start of each of your turns.",
+					},
+					[]interface{}{
+						map[string]interface{}{"type": "cell", "roll": map[string]interface{}{"min": float64(3), "max": float64(4), "pad": true}},
+						"You cast {@spell fireball} centered on yourself.",
+					},
+				},
+				Footnotes: []interface{}{"{@i Roll again on a 00.}"},
+			},
+			expected: "# Wild Magic Surge\n\n" +
+				"*Table*\n\n" +
+				"| d100 | Effect |\n" +
+				"| --- | --- |\n" +
+				"| 01–02 | Roll on this table at the start of each of your turns. |\n" +
+				"| 03–04 | You cast fireball centered on yourself. |\n\n" +
+				"^table\n\n" +
+				"**Roll:** `dice: [[Wild Magic Surge^table]]`\n\n" +
+				"*Roll again on a 00.*\n\n" +
+				"**Source:** PHB, page 104\n",
+		},
+		{
+			name: "Table without dice column",
+			table: RollTable{
+				Name:      "Languages",
+				Source:    "PHB",
+				Caption:   "Standard Languages",
+				ColLabels: []interface{}{"Language", "Script"},
+				Rows:      []interface{}{[]interface{}{"Common", "Common"}},
+			},
+			expected: "# Languages\n\n" +
+				"*Table*\n\n" +
+				"**Standard Languages**\n\n" +
+				"| Language | Script |\n" +
+				"| --- | --- |\n" +
+				"| Common | Common |\n\n" +
+				"**Source:** PHB\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := rollTableToMarkdown(tt.table)
+			if err != nil {
+				t.Fatalf("rollTableToMarkdown() error = %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("rollTableToMarkdown() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTableGroupToMarkdown(t *testing.T) {
+	group := TableGroup{
+		Name:   "Encounters",
+		Source: "XGE",
+		Tables: []RollTable{
+			{Name: "Forest", ColLabels: []interface{}{"d6", "Encounter"}, Rows: []interface{}{[]interface{}{"1", "Wolves"}}},
+			{Name: "Hills", ColLabels: []interface{}{"d6", "Encounter"}, Rows: []interface{}{[]interface{}{"1", "Giants"}}},
+		},
+	}
+
+	result, err := tableGroupToMarkdown(group)
+	if err != nil {
+		t.Fatalf("tableGroupToMarkdown() error = %v", err)
+	}
+
+	expectedStrings := []string{
+		"# Encounters\n\n*Table Group*",
+		"## Forest",
+		"`dice: [[Encounters^table-1]]`",
+		"## Hills",
+		"`dice: [[Encounters^table-2]]`",
+	}
+	for _, expected := range expectedStrings {
+		if !strings.Contains(result, expected) {
+			t.Errorf("tableGroupToMarkdown() missing %q, got %s", expected, result)
+		}
+	}
+}
+
+func TestProcessTableTag(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Roll on the {@table Trinkets|PHB} table.", "Roll on the [[Trinkets]] table."},
+		{"See {@table Wild Magic Surge|PHB|the surge table}.", "See [[Wild Magic Surge|the surge table]]."},
+	}
+
+	for _, test := range tests {
+		result := processTableTag(test.input)
+		if result != test.expected {
+			t.Errorf("processTableTag(%q) = %q; want %q", test.input, result, test.expected)
+		}
+	}
+}
+
+func TestParseTables_WithMockData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "tables-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	tableFile := TableFile{
+		Table:      []RollTable{{Name: "Trinkets", Source: "PHB", ColLabels: []interface{}{"d100", "Trinket"}}},
+		TableGroup: []TableGroup{{Name: "Encounters", Source: "XGE"}},
+	}
+	data, err := json.Marshal(tableFile)
+	if err != nil {
+		t.Fatalf("Failed to marshal table data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "tables.json"), data, 0644); err != nil {
+		t.Fatalf("Failed to write tables.json: %v", err)
+	}
+
+	if err := parseTables(context.Background(), dataDir, outDir); err != nil {
+		t.Fatalf("parseTables() error = %v", err)
+	}
+
+	for _, expectedFile := range []string{"Trinkets.md", "Encounters.md"} {
+		if _, err := os.Stat(filepath.Join(outDir, "tables", expectedFile)); os.IsNotExist(err) {
+			t.Errorf("Expected file %s does not exist", expectedFile)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// TrapHazardFile represents the structure of the trapshazards.json file
+type TrapHazardFile struct {
+	Trap   []TrapHazard `json:"trap"`
+	Hazard []TrapHazard `json:"hazard"`
+}
+
+// TrapHazard represents a single trap or hazard. Simple traps use the trigger, effect and countermeasures
+// fields, while complex traps add initiative and active, dynamic and constant elements.
+type TrapHazard struct {
+	Name            string                   `json:"name"`
+	Source          string                   `json:"source"`
+	Page            int                      `json:"page,omitempty"`
+	TrapHazType     string                   `json:"trapHazType,omitempty"`
+	Rating          []map[string]interface{} `json:"rating,omitempty"`
+	Trigger         []interface{}            `json:"trigger,omitempty"`
+	Effect          []interface{}            `json:"effect,omitempty"`
+	Initiative      int                      `json:"initiative,omitempty"`
+	InitiativeNote  string                   `json:"initiativeNote,omitempty"`
+	EActive         []interface{}            `json:"eActive,omitempty"`
+	EDynamic        []interface{}            `json:"eDynamic,omitempty"`
+	EConstant       []interface{}            `json:"eConstant,omitempty"`
+	Countermeasures []interface{}            `json:"countermeasures,omitempty"`
+	Entries         []interface{}            `json:"entries,omitempty"`
+}
+
+// parseTrapsHazards parses the trap and hazard data from the specified directory and writes it to the output directory.
+func parseTrapsHazards(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Read and parse the trap and hazard file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "trapshazards.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read trap and hazard file: %w", err)
+	}
+
+	var trapHazardFile TrapHazardFile
+	if err := json.Unmarshal(fileData, &trapHazardFile); err != nil {
+		return fmt.Errorf("failed to parse trap and hazard file: %w", err)
+	}
+
+	groups := []struct {
+		folder  string
+		hazard  bool
+		entries []TrapHazard
+	}{
+		{"traps", false, trapHazardFile.Trap},
+		{"hazards", true, trapHazardFile.Hazard},
+	}
+
+	for _, group := range groups {
+		// Create output directory if it doesn't exist
+		outDir := filepath.Join(outDirectory, group.folder)
+		if err := os.MkdirAll(outDir, 0755); err != nil {
+			return fmt.Errorf("failed to create output directory: %w", err)
+		}
+
+		// Process each trap or hazard
+		for _, trapHazard := range group.entries {
+			mdContent, err := trapHazardToMarkdown(trapHazard, group.hazard)
+			if err != nil {
+				return fmt.Errorf("failed to convert trap or hazard to markdown: %w", err)
+			}
+
+			if err := writeNote(outDir, trapHazard.Name, mdContent); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// trapHazardToMarkdown converts a trap or hazard to Markdown format. The hazard flag tells the two apart when the
+// entry has no type of its own.
+func trapHazardToMarkdown(trapHazard TrapHazard, hazard bool) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", trapHazard.Name))
+
+	// Type and rating, e.g. "*Complex Trap (levels 5–10, dangerous threat)*"
+	typeName := getTrapHazTypeName(trapHazard.TrapHazType, hazard)
+	if rating := getTrapRating(trapHazard.Rating); rating != "" {
+		typeName += fmt.Sprintf(" (%s)", rating)
+	}
+	md.WriteString(fmt.Sprintf("*%s*\n\n", typeName))
+
+	// Description
+	entriesToMarkdown(&md, trapHazard.Entries, 2)
+
+	// Trap sections, in the order they appear in the books
+	writeTrapSection(&md, "Trigger", trapHazard.Trigger)
+	writeTrapSection(&md, "Effect", trapHazard.Effect)
+
+	if trapHazard.Initiative > 0 {
+		initiative := fmt.Sprintf("The trap acts on %s", getTrapInitiative(trapHazard.Initiative))
+		if trapHazard.InitiativeNote != "" {
+			initiative += fmt.Sprintf(" (%s)", processSpecialFormatting(trapHazard.InitiativeNote))
+		}
+		writeTrapSection(&md, "Initiative", []interface{}{initiative + "."})
+	}
+
+	writeTrapSection(&md, "Active Elements", trapHazard.EActive)
+	writeTrapSection(&md, "Dynamic Elements", trapHazard.EDynamic)
+	writeTrapSection(&md, "Constant Elements", trapHazard.EConstant)
+	writeTrapSection(&md, "Countermeasures", trapHazard.Countermeasures)
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", trapHazard.Source))
+	if trapHazard.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", trapHazard.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// writeTrapSection writes a named trap section, such as the trigger or countermeasures, when it has entries
+func writeTrapSection(md *strings.Builder, name string, entries []interface{}) {
+	if len(entries) == 0 {
+		return
+	}
+	md.WriteString(fmt.Sprintf("## %s\n\n", name))
+	entriesToMarkdown(md, entries, 3)
+}
+
+// getTrapRating returns the tier and threat of a trap, e.g. "levels 1–4, moderate threat"
+func getTrapRating(rating []map[string]interface{}) string {
+	ratings := make([]string, 0, len(rating))
+	for _, r := range rating {
+		var parts []string
+		if tier, ok := r["tier"].(float64); ok {
+			parts = append(parts, getTrapTierLevels(int(tier)))
+		}
+		if threat, ok := r["threat"].(string); ok {
+			parts = append(parts, threat+" threat")
+		}
+		if len(parts) > 0 {
+			ratings = append(ratings, strings.Join(parts, ", "))
+		}
+	}
+	return strings.Join(ratings, "; ")
+}
+
+// getTrapTierLevels returns the character levels covered by a trap tier
+func getTrapTierLevels(tier int) string {
+	switch tier {
+	case 1:
+		return "levels 1–4"
+	case 2:
+		return "levels 5–10"
+	case 3:
+		return "levels 11–16"
+	case 4:
+		return "levels 17–20"
+	default:
+		return fmt.Sprintf("tier %d", tier)
+	}
+}
+
+// getTrapInitiative returns the initiative count a complex trap acts on
+func getTrapInitiative(initiative int) string {
+	switch initiative {
+	case 1:
+		return "initiative count 10"
+	case 2:
+		return "initiative count 20"
+	case 3:
+		return "initiative count 20 and initiative count 10"
+	default:
+		return fmt.Sprintf("initiative count %d", initiative)
+	}
+}
+
+// getTrapHazTypeName returns the full name of a trap or hazard type, defaulting to "Trap" or "Hazard"
+func getTrapHazTypeName(trapHazType string, hazard bool) string {
+	switch trapHazType {
+	case "MECH":
+		return "Mechanical Trap"
+	case "MAG":
+		return "Magic Trap"
+	case "SMPL":
+		return "Simple Trap"
+	case "CMPX":
+		return "Complex Trap"
+	case "HAUNT":
+		return "Haunted Trap"
+	case "TRP":
+		return "Trap"
+	case "HAZ":
+		return "Hazard"
+	case "ENV":
+		return "Environmental Hazard"
+	case "WLD":
+		return "Wilderness Hazard"
+	case "WTH":
+		return "Weather"
+	case "EST":
+		return "Eldritch Storm"
+	case "GEN":
+		return "Generic Hazard"
+	case "":
+		if hazard {
+			return "Hazard"
+		}
+		return "Trap"
+	default:
+		return trapHazType
+	}
+}
@@ -0,0 +1,139 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestTrapHazardToMarkdown_SimpleTrap(t *testing.T) {
+	trap := TrapHazard{
+		Name:            "Fire-Breathing Statue",
+		Source:          "XGE",
+		Page:            118,
+		TrapHazType:     "SMPL",
+		Rating:          []map[string]interface{}{{"tier": float64(1), "threat": "dangerous"}},
+		Trigger:         []interface{}{"A creature steps on the pressure plate."},
+		Effect:          []interface{}{"The statue releases a {@damage 4d10} blast of fire."},
+		Countermeasures: []interface{}{"A successful DC 15 Intelligence (Investigation) check reveals the plate."},
+	}
+
+	md, err := trapHazardToMarkdown(trap, false)
+	if err != nil {
+		t.Fatalf("trapHazardToMarkdown() error = %v", err)
+	}
+
+	expected := "# Fire-Breathing Statue\n\n*Simple Trap (levels 1–4, dangerous threat)*\n\n" +
+		"## Trigger\n\nA creature steps on the pressure plate.\n\n" +
+		"## Effect\n\nThe statue releases a 4d10 blast of fire.\n\n" +
+		"## Countermeasures\n\nA successful DC 15 Intelligence (Investigation) check reveals the plate.\n\n" +
+		"**Source:** XGE, page 118\n"
+	if md != expected {
+		t.Errorf("trapHazardToMarkdown() = %q, want %q", md, expected)
+	}
+}
+
+func TestTrapHazardToMarkdown_ComplexTrap(t *testing.T) {
+	trap := TrapHazard{
+		Name:           "Sphere of Crushing Doom",
+		Source:         "DMG",
+		Page:           123,
+		TrapHazType:    "CMPX",
+		Rating:         []map[string]interface{}{{"tier": float64(2), "threat": "dangerous"}},
+		Initiative:     1,
+		InitiativeNote: "the sphere moves after the characters",
+		Entries:        []interface{}{"A huge stone sphere rolls down the corridor."},
+		EActive:        []interface{}{"The sphere moves 60 feet."},
+		EDynamic:       []interface{}{"The sphere speeds up each round."},
+		EConstant:      []interface{}{"Creatures in the path are crushed."},
+	}
+
+	md, err := trapHazardToMarkdown(trap, false)
+	if err != nil {
+		t.Fatalf("trapHazardToMarkdown() error = %v", err)
+	}
+
+	expectedElements := []string{
+		"*Complex Trap (levels 5–10, dangerous threat)*\n\n",
+		"A huge stone sphere rolls down the corridor.\n\n",
+		"## Initiative\n\nThe trap acts on initiative count 10 (the sphere moves after the characters).\n\n",
+		"## Active Elements\n\nThe sphere moves 60 feet.\n\n",
+		"## Dynamic Elements\n\nThe sphere speeds up each round.\n\n",
+		"## Constant Elements\n\nCreatures in the path are crushed.\n\n",
+	}
+	for _, expected := range expectedElements {
+		if !strings.Contains(md, expected) {
+			t.Errorf("trapHazardToMarkdown() output missing expected element: %q\n%s", expected, md)
+		}
+	}
+}
+
+func TestGetTrapHazTypeName(t *testing.T) {
+	tests := []struct {
+		trapHazType string
+		hazard      bool
+		expected    string
+	}{
+		{"MECH", false, "Mechanical Trap"},
+		{"ENV", true, "Environmental Hazard"},
+		{"", false, "Trap"},
+		{"", true, "Hazard"},
+	}
+
+	for _, tt := range tests {
+		if result := getTrapHazTypeName(tt.trapHazType, tt.hazard); result != tt.expected {
+			t.Errorf("getTrapHazTypeName(%q, %v) = %q, want %q", tt.trapHazType, tt.hazard, result, tt.expected)
+		}
+	}
+}
+
+func TestProcessHazardTag(t *testing.T) {
+	result := processHazardTag("Take {@hazard burning|XPHB} damage from the {@trap pit trap|XGE}.")
+	expected := "Take [[burning]] damage from the [[pit trap]]."
+	if result != expected {
+		t.Errorf("processHazardTag() = %q; want %q", result, expected)
+	}
+}
+
+func TestParseTrapsHazards_WithMockData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "traps-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	trapHazardFile := TrapHazardFile{
+		Trap:   []TrapHazard{{Name: "Collapsing Roof", Source: "DMG", TrapHazType: "MECH"}},
+		Hazard: []TrapHazard{{Name: "Brown Mold", Source: "DMG", TrapHazType: "ENV"}},
+	}
+	data, err := json.Marshal(trapHazardFile)
+	if err != nil {
+		t.Fatalf("Failed to marshal trap data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "trapshazards.json"), data, 0644); err != nil {
+		t.Fatalf("Failed to write trapshazards.json: %v", err)
+	}
+
+	if err := parseTrapsHazards(context.Background(), dataDir, outDir); err != nil {
+		t.Fatalf("parseTrapsHazards() error = %v", err)
+	}
+
+	expectedFiles := []string{
+		filepath.Join("traps", "Collapsing Roof.md"),
+		filepath.Join("hazards", "Brown Mold.md"),
+	}
+	for _, expectedFile := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(outDir, expectedFile)); os.IsNotExist(err) {
+			t.Errorf("Expected file %s does not exist", expectedFile)
+		}
+	}
+}
@@ -0,0 +1,97 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// VariantRuleFile represents the structure of the variantrules.json file
+type VariantRuleFile struct {
+	VariantRule []VariantRule `json:"variantrule"`
+}
+
+// VariantRule represents a single core, optional or variant rule
+type VariantRule struct {
+	Name     string        `json:"name"`
+	Source   string        `json:"source"`
+	Page     int           `json:"page,omitempty"`
+	RuleType string        `json:"ruleType,omitempty"`
+	Entries  []interface{} `json:"entries,omitempty"`
+}
+
+// parseVariantRules parses the variant rule data from the specified directory and writes it to the output directory.
+func parseVariantRules(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Create output directory if it doesn't exist
+	outDir := filepath.Join(outDirectory, "variant-rules")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Read and parse the variant rule file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "variantrules.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read variant rule file: %w", err)
+	}
+
+	var variantRuleFile VariantRuleFile
+	if err := json.Unmarshal(fileData, &variantRuleFile); err != nil {
+		return fmt.Errorf("failed to parse variant rule file: %w", err)
+	}
+
+	// Process each variant rule
+	for _, rule := range variantRuleFile.VariantRule {
+		mdContent, err := variantRuleToMarkdown(rule)
+		if err != nil {
+			return fmt.Errorf("failed to convert variant rule to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, rule.Name, mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// variantRuleToMarkdown converts a variant rule to Markdown format
+func variantRuleToMarkdown(rule VariantRule) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", rule.Name))
+	md.WriteString(fmt.Sprintf("*%s*\n\n", getRuleTypeName(rule.RuleType)))
+
+	// Description, with nested sections starting at the second heading level
+	entriesToMarkdown(&md, rule.Entries, 2)
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", rule.Source))
+	if rule.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", rule.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// getRuleTypeName returns the full name of a rule type
+func getRuleTypeName(ruleType string) string {
+	switch ruleType {
+	case "C":
+		return "Core Rule"
+	case "O":
+		return "Optional Rule"
+	case "V":
+		return "Variant Rule"
+	case "VO":
+		return "Variant Optional Rule"
+	case "VV":
+		return "Variant Variant Rule"
+	default:
+		return "Rule"
+	}
+}
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestVariantRuleToMarkdown(t *testing.T) {
+	rule := VariantRule{
+		Name:     "Flanking",
+		Source:   "DMG",
+		Page:     251,
+		RuleType: "O",
+		Entries: []interface{}{
+			"If you regularly use miniatures, flanking gives combatants a simple way to gain advantage.",
+			map[string]interface{}{
+				"type": "entries",
+				"name": "Flanking on Squares",
+				"entries": []interface{}{
+					"When a creature and at least one of its allies are adjacent to an enemy and on opposite sides, they flank that enemy.",
+				},
+			},
+			map[string]interface{}{
+				"type":    "inset",
+				"name":    "Variant: Facing",
+				"entries": []interface{}{"Every creature has a front arc."},
+			},
+		},
+	}
+
+	md, err := variantRuleToMarkdown(rule)
+	if err != nil {
+		t.Fatalf("variantRuleToMarkdown() error = %v", err)
+	}
+
+	expected := "# Flanking\n\n*Optional Rule*\n\n" +
+		"If you regularly use miniatures, flanking gives combatants a simple way to gain advantage.\n\n" +
+		"## Flanking on Squares\n\n" +
+		"When a creature and at least one of its allies are adjacent to an enemy and on opposite sides, they flank that enemy.\n\n" +
+		"> **Variant: Facing**\n>\n> Every creature has a front arc.\n\n" +
+		"**Source:** DMG, page 251\n"
+	if md != expected {
+		t.Errorf("variantRuleToMarkdown() = %q, want %q", md, expected)
+	}
+}
+
+func TestProcessVariantRuleTag(t *testing.T) {
+	result := processSpecialFormatting("Regain {@variantrule Hit Points|XPHB} or {@variantrule Hit Points|XPHB|HP}.")
+	expected := "Regain [[Hit Points]] or [[Hit Points|HP]]."
+	if result != expected {
+		t.Errorf("processSpecialFormatting() = %q; want %q", result, expected)
+	}
+}
+
+func TestParseVariantRules_WithMockData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "variantrules-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	variantRuleFile := VariantRuleFile{
+		VariantRule: []VariantRule{
+			{Name: "Flanking", Source: "DMG", RuleType: "O"},
+			{Name: "Hit Points", Source: "XPHB", RuleType: "C"},
+		},
+	}
+	data, err := json.Marshal(variantRuleFile)
+	if err != nil {
+		t.Fatalf("Failed to marshal variant rule data: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dataDir, "variantrules.json"), data, 0644); err != nil {
+		t.Fatalf("Failed to write variantrules.json: %v", err)
+	}
+
+	if err := parseVariantRules(context.Background(), dataDir, outDir); err != nil {
+		t.Fatalf("parseVariantRules() error = %v", err)
+	}
+
+	for _, expectedFile := range []string{"Flanking.md", "Hit Points.md"} {
+		if _, err := os.Stat(filepath.Join(outDir, "variant-rules", expectedFile)); os.IsNotExist(err) {
+			t.Errorf("Expected file %s does not exist", expectedFile)
+		}
+	}
+}
@@ -0,0 +1,632 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// VehicleFile represents the structure of the vehicles.json file
+type VehicleFile struct {
+	Vehicle []Vehicle `json:"vehicle"`
+}
+
+// Vehicle represents a single ship, infernal war machine, spelljammer or other vehicle.
+// Ships and spelljammers have hull, control, movement and weapon components with their own stats,
+// while infernal war machines have a single AC and HP with action stations.
+type Vehicle struct {
+	Name            string                   `json:"name"`
+	Source          string                   `json:"source"`
+	Page            int                      `json:"page,omitempty"`
+	VehicleType     string                   `json:"vehicleType"`
+	Size            string                   `json:"size,omitempty"`
+	Dimensions      []string                 `json:"dimensions,omitempty"`
+	CapCrew         int                      `json:"capCrew,omitempty"`
+	CapCrewNote     string                   `json:"capCrewNote,omitempty"`
+	CapPassenger    int                      `json:"capPassenger,omitempty"`
+	CapCargo        interface{}              `json:"capCargo,omitempty"` // Can be number or string
+	AC              interface{}              `json:"ac,omitempty"`       // Used by infernal war machines
+	HP              interface{}              `json:"hp,omitempty"`       // Can be number or object with hp, dt and mt
+	Speed           interface{}              `json:"speed,omitempty"`    // Can be number or object by mode
+	Pace            interface{}              `json:"pace,omitempty"`     // Can be number or object by mode
+	STR             int                      `json:"str,omitempty"`
+	DEX             int                      `json:"dex,omitempty"`
+	CON             int                      `json:"con,omitempty"`
+	INT             int                      `json:"int,omitempty"`
+	WIS             int                      `json:"wis,omitempty"`
+	CHA             int                      `json:"cha,omitempty"`
+	Immune          []interface{}            `json:"immune,omitempty"`
+	Resist          []interface{}            `json:"resist,omitempty"`
+	Vulnerable      []interface{}            `json:"vulnerable,omitempty"`
+	ConditionImmune []interface{}            `json:"conditionImmune,omitempty"`
+	Hull            map[string]interface{}   `json:"hull,omitempty"`
+	Control         []map[string]interface{} `json:"control,omitempty"`
+	Movement        []map[string]interface{} `json:"movement,omitempty"`
+	Weapon          []map[string]interface{} `json:"weapon,omitempty"`
+	Other           []map[string]interface{} `json:"other,omitempty"`
+	Trait           []MonsterTrait           `json:"trait,omitempty"`
+	Action          []interface{}            `json:"action,omitempty"`
+	ActionStation   []MonsterTrait           `json:"actionStation,omitempty"`
+	Reaction        []MonsterTrait           `json:"reaction,omitempty"`
+	Entries         []interface{}            `json:"entries,omitempty"`
+}
+
+// ObjectFile represents the structure of the objects.json file
+type ObjectFile struct {
+	Object []Object `json:"object"`
+}
+
+// Object represents a single object, such as a siege weapon
+type Object struct {
+	Name            string        `json:"name"`
+	Source          string        `json:"source"`
+	Page            int           `json:"page,omitempty"`
+	Size            interface{}   `json:"size,omitempty"` // Can be string or array
+	ObjectType      string        `json:"objectType,omitempty"`
+	AC              interface{}   `json:"ac,omitempty"` // Can be number or string
+	HP              interface{}   `json:"hp,omitempty"` // Can be number or string
+	Speed           interface{}   `json:"speed,omitempty"`
+	STR             int           `json:"str,omitempty"`
+	DEX             int           `json:"dex,omitempty"`
+	CON             int           `json:"con,omitempty"`
+	INT             int           `json:"int,omitempty"`
+	WIS             int           `json:"wis,omitempty"`
+	CHA             int           `json:"cha,omitempty"`
+	Immune          []interface{} `json:"immune,omitempty"`
+	Resist          []interface{} `json:"resist,omitempty"`
+	Vulnerable      []interface{} `json:"vulnerable,omitempty"`
+	ConditionImmune []interface{} `json:"conditionImmune,omitempty"`
+	Entries         []interface{} `json:"entries,omitempty"`
+	ActionEntries   []interface{} `json:"actionEntries,omitempty"`
+}
+
+// parseVehicles parses the vehicle data from the specified directory and writes it to the output directory.
+func parseVehicles(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Create output directory if it doesn't exist
+	outDir := filepath.Join(outDirectory, "vehicles")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Read and parse the vehicle file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "vehicles.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read vehicle file: %w", err)
+	}
+
+	var vehicleFile VehicleFile
+	if err := json.Unmarshal(fileData, &vehicleFile); err != nil {
+		return fmt.Errorf("failed to parse vehicle file: %w", err)
+	}
+
+	// Process each vehicle
+	for _, vehicle := range vehicleFile.Vehicle {
+		mdContent, err := vehicleToMarkdown(vehicle)
+		if err != nil {
+			return fmt.Errorf("failed to convert vehicle to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, vehicle.Name, mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// parseObjects parses the object data from the specified directory and writes it to the output directory.
+func parseObjects(ctx context.Context, dataDirectory, outDirectory string) error {
+	// Create output directory if it doesn't exist
+	outDir := filepath.Join(outDirectory, "objects")
+	if err := os.MkdirAll(outDir, 0755); err != nil {
+		return fmt.Errorf("failed to create output directory: %w", err)
+	}
+
+	// Read and parse the object file
+	fileData, err := os.ReadFile(filepath.Join(dataDirectory, "objects.json"))
+	if err != nil {
+		return fmt.Errorf("failed to read object file: %w", err)
+	}
+
+	var objectFile ObjectFile
+	if err := json.Unmarshal(fileData, &objectFile); err != nil {
+		return fmt.Errorf("failed to parse object file: %w", err)
+	}
+
+	// Process each object
+	for _, object := range objectFile.Object {
+		mdContent, err := objectToMarkdown(object)
+		if err != nil {
+			return fmt.Errorf("failed to convert object to markdown: %w", err)
+		}
+
+		if err := writeNote(outDir, object.Name, mdContent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// vehicleToMarkdown converts a vehicle to Markdown format
+func vehicleToMarkdown(vehicle Vehicle) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", vehicle.Name))
+
+	// Size, type and dimensions, e.g. "*Gargantuan vehicle (130 ft. by 20 ft.)*"
+	header := fmt.Sprintf("%s %s", getSizeString(vehicle.Size), getVehicleTypeName(vehicle.VehicleType))
+	if len(vehicle.Dimensions) > 0 {
+		header += fmt.Sprintf(" (%s)", strings.Join(vehicle.Dimensions, " by "))
+	}
+	md.WriteString(fmt.Sprintf("*%s*\n\n", strings.TrimSpace(header)))
+
+	// Capacity
+	var capacity []string
+	if vehicle.CapCrew > 0 {
+		crew := fmt.Sprintf("%d crew", vehicle.CapCrew)
+		if vehicle.CapCrewNote != "" {
+			crew += fmt.Sprintf(" (%s)", vehicle.CapCrewNote)
+		}
+		capacity = append(capacity, crew)
+	}
+	if vehicle.CapPassenger > 0 {
+		capacity = append(capacity, fmt.Sprintf("%d passengers", vehicle.CapPassenger))
+	}
+	if len(capacity) > 0 {
+		md.WriteString(fmt.Sprintf("**Creature Capacity** %s\n\n", strings.Join(capacity, ", ")))
+	}
+
+	switch cargo := vehicle.CapCargo.(type) {
+	case float64:
+		// Infernal war machines measure cargo in pounds, ships in tons
+		if vehicle.VehicleType == "INFWAR" {
+			md.WriteString(fmt.Sprintf("**Cargo Capacity** %s lb.\n\n", formatNumber(cargo)))
+		} else {
+			md.WriteString(fmt.Sprintf("**Cargo Capacity** %s %s\n\n", formatNumber(cargo), pluralize(int(cargo), "ton", "tons")))
+		}
+	case string:
+		md.WriteString(fmt.Sprintf("**Cargo Capacity** %s\n\n", cargo))
+	}
+
+	if pace := formatVehiclePace(vehicle.Pace); pace != "" {
+		md.WriteString(fmt.Sprintf("**Travel Pace** %s\n\n", pace))
+	}
+
+	// Vehicle-wide AC, HP and speed, used by infernal war machines
+	if vehicle.AC != nil {
+		md.WriteString(fmt.Sprintf("**Armor Class** %v\n\n", vehicle.AC))
+	}
+	if hp := formatComponentHP(vehicle.HP, 0); hp != "" {
+		md.WriteString(fmt.Sprintf("**Hit Points** %s\n\n", hp))
+	}
+	if speed := formatVehicleSpeed(vehicle.Speed); speed != "" {
+		md.WriteString(fmt.Sprintf("**Speed** %s\n\n", speed))
+	}
+
+	// Ability Scores
+	if vehicle.STR > 0 || vehicle.DEX > 0 || vehicle.CON > 0 {
+		writeAbilityScores(&md, vehicle.STR, vehicle.DEX, vehicle.CON, vehicle.INT, vehicle.WIS, vehicle.CHA)
+	}
+
+	writeDamageModifiers(&md, vehicle.Vulnerable, vehicle.Resist, vehicle.Immune, vehicle.ConditionImmune)
+
+	// Description
+	entriesToMarkdown(&md, vehicle.Entries, 2)
+
+	// Traits
+	writeVehicleTraits(&md, "Traits", vehicle.Trait)
+
+	// Actions, e.g. a ship's crew actions
+	if len(vehicle.Action) > 0 {
+		md.WriteString("## Actions\n\n")
+		entriesToMarkdown(&md, vehicle.Action, 3)
+	}
+
+	writeVehicleTraits(&md, "Action Stations", vehicle.ActionStation)
+	writeVehicleTraits(&md, "Reactions", vehicle.Reaction)
+
+	// Components with their own stats
+	if vehicle.Hull != nil {
+		md.WriteString("## Hull\n\n")
+		writeVehicleComponent(&md, vehicle.Hull)
+	}
+	for _, control := range vehicle.Control {
+		writeNamedVehicleComponent(&md, "Control", control)
+	}
+	for _, movement := range vehicle.Movement {
+		writeNamedVehicleComponent(&md, "Movement", movement)
+	}
+	for _, weapon := range vehicle.Weapon {
+		writeNamedVehicleComponent(&md, "Weapons", weapon)
+	}
+	for _, other := range vehicle.Other {
+		writeNamedVehicleComponent(&md, "Other", other)
+	}
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", vehicle.Source))
+	if vehicle.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", vehicle.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// objectToMarkdown converts an object to Markdown format
+func objectToMarkdown(object Object) (string, error) {
+	var md strings.Builder
+
+	// Title
+	md.WriteString(fmt.Sprintf("# %s\n\n", object.Name))
+
+	// Size and type, e.g. "*Large object (siege weapon)*"
+	var sizeStr string
+	switch s := object.Size.(type) {
+	case string:
+		sizeStr = getSizeString(s)
+	case []interface{}:
+		sizes := make([]string, 0, len(s))
+		for _, size := range s {
+			if sizeVal, ok := size.(string); ok {
+				sizes = append(sizes, getSizeString(sizeVal))
+			}
+		}
+		sizeStr = joinWithOr(sizes)
+	}
+	header := strings.TrimSpace(sizeStr + " object")
+	if objectType := getObjectTypeName(object.ObjectType); objectType != "" {
+		header += fmt.Sprintf(" (%s)", objectType)
+	}
+	md.WriteString(fmt.Sprintf("*%s*\n\n", header))
+
+	if object.AC != nil {
+		md.WriteString(fmt.Sprintf("**Armor Class** %v\n\n", object.AC))
+	}
+	if object.HP != nil {
+		md.WriteString(fmt.Sprintf("**Hit Points** %v\n\n", object.HP))
+	}
+	if speed := formatVehicleSpeed(object.Speed); speed != "" {
+		md.WriteString(fmt.Sprintf("**Speed** %s\n\n", speed))
+	}
+
+	// Ability Scores
+	if object.STR > 0 || object.DEX > 0 || object.CON > 0 {
+		writeAbilityScores(&md, object.STR, object.DEX, object.CON, object.INT, object.WIS, object.CHA)
+	}
+
+	writeDamageModifiers(&md, object.Vulnerable, object.Resist, object.Immune, object.ConditionImmune)
+
+	// Description
+	entriesToMarkdown(&md, object.Entries, 2)
+
+	// Actions
+	if len(object.ActionEntries) > 0 {
+		md.WriteString("## Actions\n\n")
+		entriesToMarkdown(&md, object.ActionEntries, 3)
+	}
+
+	// Source
+	md.WriteString(fmt.Sprintf("**Source:** %s", object.Source))
+	if object.Page > 0 {
+		md.WriteString(fmt.Sprintf(", page %d", object.Page))
+	}
+	md.WriteString("\n")
+
+	return md.String(), nil
+}
+
+// writeNamedVehicleComponent writes a vehicle component such as a helm or ballista under a heading
+// that includes the component group, e.g. "## Weapons: Ballista (4)"
+func writeNamedVehicleComponent(md *strings.Builder, group string, component map[string]interface{}) {
+	name, _ := component["name"].(string)
+	heading := fmt.Sprintf("%s: %s", group, name)
+	if count, ok := component["count"].(float64); ok && count > 1 {
+		heading += fmt.Sprintf(" (%d)", int(count))
+	}
+	md.WriteString(fmt.Sprintf("## %s\n\n", strings.TrimSuffix(heading, ": ")))
+	writeVehicleComponent(md, component)
+}
+
+// writeVehicleComponent writes the stats and entries of a vehicle component
+func writeVehicleComponent(md *strings.Builder, component map[string]interface{}) {
+	count := 0
+	if c, ok := component["count"].(float64); ok {
+		count = int(c)
+	}
+
+	if crew, ok := component["crew"].(float64); ok {
+		md.WriteString(fmt.Sprintf("**Crew** %d\n\n", int(crew)))
+	}
+	if ac, ok := component["ac"]; ok {
+		md.WriteString(fmt.Sprintf("**Armor Class** %v\n\n", ac))
+	}
+	if hp := formatComponentHP(component, count); hp != "" {
+		md.WriteString(fmt.Sprintf("**Hit Points** %s\n\n", hp))
+	}
+	if speed := formatVehicleSpeed(component["speed"]); speed != "" {
+		md.WriteString(fmt.Sprintf("**Speed** %s\n\n", speed))
+	}
+
+	// Costs, e.g. for spelljammer weapons
+	if costs, ok := component["costs"].([]interface{}); ok {
+		costTexts := make([]string, 0, len(costs))
+		for _, cost := range costs {
+			if costMap, ok := cost.(map[string]interface{}); ok {
+				text := ""
+				// Costs are stored in copper pieces
+				if value, ok := costMap["cost"].(float64); ok {
+					text = formatCoins(value)
+				}
+				if note, ok := costMap["note"].(string); ok {
+					text = strings.TrimSpace(fmt.Sprintf("%s (%s)", text, note))
+				}
+				costTexts = append(costTexts, text)
+			}
+		}
+		md.WriteString(fmt.Sprintf("**Cost** %s\n\n", strings.Join(costTexts, ", ")))
+	}
+
+	// Ship movement components list locomotion and speed per mode
+	for _, key := range []string{"locomotion", "speed"} {
+		modes, ok := component[key].([]interface{})
+		if !ok {
+			continue
+		}
+		for _, mode := range modes {
+			modeMap, ok := mode.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			modeName, _ := modeMap["mode"].(string)
+			md.WriteString(fmt.Sprintf("**%s (%s).** ", titleCase(key), modeName))
+			entries, _ := modeMap["entries"].([]interface{})
+			texts := make([]string, 0, len(entries))
+			for _, entry := range entries {
+				texts = append(texts, listItemToMarkdown(entry))
+			}
+			md.WriteString(strings.Join(texts, " ") + "\n\n")
+		}
+	}
+
+	if entries, ok := component["entries"].([]interface{}); ok {
+		entriesToMarkdown(md, entries, 3)
+	}
+
+	// Spelljammer weapons have their own actions
+	if actions, ok := component["action"].([]interface{}); ok {
+		for _, action := range actions {
+			entryToMarkdown(md, action, 3)
+		}
+	}
+}
+
+// formatComponentHP formats the hit points of a vehicle or component, including its damage and mishap thresholds.
+// The value can be a plain number or an object with "hp", "dt", "mt" and "hpNote" fields.
+func formatComponentHP(value interface{}, count int) string {
+	var hp string
+	var thresholds []string
+	var note string
+
+	switch v := value.(type) {
+	case float64:
+		hp = formatNumber(v)
+	case map[string]interface{}:
+		switch h := v["hp"].(type) {
+		case float64:
+			hp = formatNumber(h)
+		case string:
+			hp = h
+		}
+		if dt, ok := v["dt"].(float64); ok {
+			thresholds = append(thresholds, fmt.Sprintf("damage threshold %d", int(dt)))
+		}
+		if mt, ok := v["mt"].(float64); ok {
+			thresholds = append(thresholds, fmt.Sprintf("mishap threshold %d", int(mt)))
+		}
+		note, _ = v["hpNote"].(string)
+	}
+
+	if hp == "" {
+		return ""
+	}
+	if count > 1 {
+		hp += " each"
+	}
+	if len(thresholds) > 0 {
+		hp += fmt.Sprintf(" (%s)", strings.Join(thresholds, ", "))
+	}
+	if note != "" {
+		hp += "; " + processSpecialFormatting(note)
+	}
+	return hp
+}
+
+// formatVehicleSpeed formats a speed that can be a number of feet or an object of speeds by mode
+func formatVehicleSpeed(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return fmt.Sprintf("%s ft.", formatNumber(v))
+	case string:
+		return v
+	case map[string]interface{}:
+		var speeds []string
+		for _, mode := range []string{"walk", "fly", "swim", "climb", "burrow"} {
+			if speed, ok := v[mode].(float64); ok {
+				if mode == "walk" {
+					speeds = append(speeds, fmt.Sprintf("%s ft.", formatNumber(speed)))
+				} else {
+					speeds = append(speeds, fmt.Sprintf("%s %s ft.", mode, formatNumber(speed)))
+				}
+			}
+		}
+		if note, ok := v["note"].(string); ok {
+			speeds = append(speeds, note)
+		}
+		return strings.Join(speeds, ", ")
+	}
+	return ""
+}
+
+// formatVehiclePace formats a travel pace in miles per hour, with the distance covered in a day
+func formatVehiclePace(value interface{}) string {
+	switch v := value.(type) {
+	case float64:
+		return fmt.Sprintf("%s miles per hour (%s miles per day)", formatNumber(v), formatNumber(v*24))
+	case map[string]interface{}:
+		var paces []string
+		for _, mode := range []string{"walk", "fly", "swim", "water", "land", "air"} {
+			if pace, ok := v[mode].(float64); ok {
+				paces = append(paces, fmt.Sprintf("%s %s miles per hour (%s miles per day)", mode, formatNumber(pace), formatNumber(pace*24)))
+			}
+		}
+		return strings.Join(paces, ", ")
+	}
+	return ""
+}
+
+// writeVehicleTraits writes a list of named traits, action stations or reactions under a heading
+func writeVehicleTraits(md *strings.Builder, heading string, traits []MonsterTrait) {
+	if len(traits) == 0 {
+		return
+	}
+	md.WriteString(fmt.Sprintf("## %s\n\n", heading))
+	for _, trait := range traits {
+		md.WriteString(fmt.Sprintf("***%s.*** ", trait.Name))
+		texts := make([]string, 0, len(trait.Entries))
+		for _, entry := range trait.Entries {
+			texts = append(texts, listItemToMarkdown(entry))
+		}
+		md.WriteString(strings.Join(texts, " "))
+		md.WriteString("\n\n")
+	}
+}
+
+// writeAbilityScores writes the ability score table used by monster, vehicle and object stat blocks
+func writeAbilityScores(md *strings.Builder, str, dex, con, intelligence, wis, cha int) {
+	md.WriteString("|STR|DEX|CON|INT|WIS|CHA|\n")
+	md.WriteString("|:---:|:---:|:---:|:---:|:---:|:---:|\n")
+	md.WriteString(fmt.Sprintf("|%d (%+d)|%d (%+d)|%d (%+d)|%d (%+d)|%d (%+d)|%d (%+d)|\n\n",
+		str, getAbilityModifier(str),
+		dex, getAbilityModifier(dex),
+		con, getAbilityModifier(con),
+		intelligence, getAbilityModifier(intelligence),
+		wis, getAbilityModifier(wis),
+		cha, getAbilityModifier(cha)))
+}
+
+// writeDamageModifiers writes the damage vulnerabilities, resistances and immunities and the condition immunities
+func writeDamageModifiers(md *strings.Builder, vulnerable, resist, immune, conditionImmune []interface{}) {
+	if text := formatDamageList(vulnerable); text != "" {
+		md.WriteString(fmt.Sprintf("**Damage Vulnerabilities** %s\n\n", text))
+	}
+	if text := formatDamageList(resist); text != "" {
+		md.WriteString(fmt.Sprintf("**Damage Resistances** %s\n\n", text))
+	}
+	if text := formatDamageList(immune); text != "" {
+		md.WriteString(fmt.Sprintf("**Damage Immunities** %s\n\n", text))
+	}
+	if text := formatDamageList(conditionImmune); text != "" {
+		md.WriteString(fmt.Sprintf("**Condition Immunities** %s\n\n", processConditionTag(text)))
+	}
+}
+
+// formatDamageList formats a list of damage types or conditions. Entries can be plain strings or
+// objects such as {"immune": ["bludgeoning"], "note": "from nonmagical attacks"}.
+func formatDamageList(list []interface{}) string {
+	var plain []string
+	var groups []string
+	for _, item := range list {
+		switch i := item.(type) {
+		case string:
+			plain = append(plain, i)
+		case map[string]interface{}:
+			if special, ok := i["special"].(string); ok {
+				groups = append(groups, special)
+				continue
+			}
+			var inner []interface{}
+			for _, key := range []string{"immune", "resist", "vulnerable", "conditionImmune"} {
+				if values, ok := i[key].([]interface{}); ok {
+					inner = values
+				}
+			}
+			text := formatDamageList(inner)
+			if preNote, ok := i["preNote"].(string); ok {
+				text = preNote + " " + text
+			}
+			if note, ok := i["note"].(string); ok {
+				text += " " + note
+			}
+			groups = append(groups, text)
+		}
+	}
+
+	if len(plain) > 0 {
+		groups = append([]string{strings.Join(plain, ", ")}, groups...)
+	}
+	return strings.Join(groups, "; ")
+}
+
+// getVehicleTypeName returns the name of a vehicle type as used in stat block headers
+func getVehicleTypeName(vehicleType string) string {
+	switch vehicleType {
+	case "SHIP":
+		return "ship"
+	case "SPELLJAMMER":
+		return "spelljammer ship"
+	case "INFWAR":
+		return "infernal war machine"
+	case "ELEMENTAL_AIRSHIP":
+		return "elemental airship"
+	case "CREATURE":
+		return "creature vehicle"
+	case "OBJECT":
+		return "object vehicle"
+	default:
+		return "vehicle"
+	}
+}
+
+// getObjectTypeName returns the name of an object type
+func getObjectTypeName(objectType string) string {
+	switch objectType {
+	case "SW":
+		return "siege weapon"
+	case "GEN":
+		return "generic"
+	case "U", "":
+		return ""
+	default:
+		return objectType
+	}
+}
+
+// formatNumber formats a number with thousands separators, keeping up to two decimals for fractions
+func formatNumber(value float64) string {
+	if value != float64(int64(value)) {
+		return strings.TrimRight(strings.TrimRight(fmt.Sprintf("%.2f", value), "0"), ".")
+	}
+
+	digits := fmt.Sprintf("%d", int64(value))
+	negative := strings.HasPrefix(digits, "-")
+	digits = strings.TrimPrefix(digits, "-")
+
+	var result strings.Builder
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			result.WriteString(",")
+		}
+		result.WriteRune(digit)
+	}
+
+	if negative {
+		return "-" + result.String()
+	}
+	return result.String()
+}
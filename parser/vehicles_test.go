@@ -0,0 +1,211 @@
+package parser
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestVehicleToMarkdown_Ship(t *testing.T) {
+	vehicleJSON := `{
+		"name": "Galley",
+		"source": "GoS",
+		"page": 210,
+		"vehicleType": "SHIP",
+		"size": "G",
+		"dimensions": ["130 ft.", "20 ft."],
+		"capCrew": 80,
+		"capPassenger": 40,
+		"capCargo": 150,
+		"pace": 4,
+		"str": 24, "dex": 4, "con": 20, "int": 0, "wis": 0, "cha": 0,
+		"immune": ["poison", "psychic"],
+		"conditionImmune": ["blinded", "charmed"],
+		"action": ["On its turn, the galley can take 3 actions, choosing from the options below."],
+		"hull": {"ac": 15, "hp": 500, "dt": 20},
+		"control": [{"name": "Helm", "ac": 16, "hp": 50, "entries": ["Move up to the speed of one of the ship's movement components."]}],
+		"movement": [{
+			"name": "Oars",
+			"ac": 12,
+			"hp": 100,
+			"locomotion": [{"mode": "water", "entries": ["Oars."]}],
+			"speed": [{"mode": "water", "entries": ["speed 20 ft."]}]
+		}],
+		"weapon": [{"name": "Ballista", "count": 4, "ac": 15, "hp": 50, "entries": ["{@atk rw} {@hit 6} to hit."]}]
+	}`
+
+	var vehicle Vehicle
+	if err := json.Unmarshal([]byte(vehicleJSON), &vehicle); err != nil {
+		t.Fatalf("Failed to unmarshal vehicle JSON: %v", err)
+	}
+
+	md, err := vehicleToMarkdown(vehicle)
+	if err != nil {
+		t.Fatalf("vehicleToMarkdown() error = %v", err)
+	}
+
+	expectedElements := []string{
+		"# Galley\n\n",
+		"*Gargantuan ship (130 ft. by 20 ft.)*\n\n",
+		"**Creature Capacity** 80 crew, 40 passengers\n\n",
+		"**Cargo Capacity** 150 tons\n\n",
+		"**Travel Pace** 4 miles per hour (96 miles per day)\n\n",
+		"|24 (+7)|4 (-3)|20 (+5)|0 (-5)|0 (-5)|0 (-5)|",
+		"**Damage Immunities** poison, psychic\n\n",
+		"**Condition Immunities** blinded, charmed\n\n",
+		"## Actions\n\nOn its turn, the galley can take 3 actions, choosing from the options below.\n\n",
+		"## Hull\n\n**Armor Class** 15\n\n**Hit Points** 500 (damage threshold 20)\n\n",
+		"## Control: Helm\n\n**Armor Class** 16\n\n**Hit Points** 50\n\nMove up to the speed of one of the ship's movement components.\n\n",
+		"## Movement: Oars\n\n**Armor Class** 12\n\n**Hit Points** 100\n\n**Locomotion (water).** Oars.\n\n**Speed (water).** speed 20 ft.\n\n",
+		"## Weapons: Ballista (4)\n\n**Armor Class** 15\n\n**Hit Points** 50 each\n\n(ranged weapon) +6 to hit.\n\n",
+		"**Source:** GoS, page 210\n",
+	}
+	for _, expected := range expectedElements {
+		if !strings.Contains(md, expected) {
+			t.Errorf("vehicleToMarkdown() output missing expected element: %q\n%s", expected, md)
+		}
+	}
+}
+
+func TestVehicleToMarkdown_InfernalWarMachine(t *testing.T) {
+	vehicle := Vehicle{
+		Name:         "Devil's Ride",
+		Source:       "BGDIA",
+		Page:         219,
+		VehicleType:  "INFWAR",
+		Size:         "L",
+		CapCrew:      1,
+		CapPassenger: 1,
+		CapCargo:     float64(1000),
+		AC:           float64(20),
+		HP:           map[string]interface{}{"hp": float64(30), "dt": float64(10), "mt": float64(10)},
+		Speed:        float64(120),
+		ActionStation: []MonsterTrait{
+			{Name: "Helm", Entries: []interface{}{"Drive and steer the Devil's Ride."}},
+		},
+	}
+
+	md, err := vehicleToMarkdown(vehicle)
+	if err != nil {
+		t.Fatalf("vehicleToMarkdown() error = %v", err)
+	}
+
+	expectedElements := []string{
+		"*Large infernal war machine*\n\n",
+		"**Cargo Capacity** 1,000 lb.\n\n",
+		"**Armor Class** 20\n\n",
+		"**Hit Points** 30 (damage threshold 10, mishap threshold 10)\n\n",
+		"**Speed** 120 ft.\n\n",
+		"## Action Stations\n\n***Helm.*** Drive and steer the Devil's Ride.\n\n",
+	}
+	for _, expected := range expectedElements {
+		if !strings.Contains(md, expected) {
+			t.Errorf("vehicleToMarkdown() output missing expected element: %q\n%s", expected, md)
+		}
+	}
+}
+
+func TestObjectToMarkdown(t *testing.T) {
+	object := Object{
+		Name:       "Ballista",
+		Source:     "DMG",
+		Page:       255,
+		Size:       "L",
+		ObjectType: "SW",
+		AC:         float64(15),
+		HP:         float64(50),
+		Immune:     []interface{}{"poison", "psychic"},
+		Entries:    []interface{}{"A ballista is a massive crossbow that fires heavy bolts."},
+		ActionEntries: []interface{}{
+			map[string]interface{}{
+				"type":    "entries",
+				"name":    "Bolt",
+				"entries": []interface{}{"{@atk rw} {@hit 6} to hit, range 120/480 ft."},
+			},
+		},
+	}
+
+	md, err := objectToMarkdown(object)
+	if err != nil {
+		t.Fatalf("objectToMarkdown() error = %v", err)
+	}
+
+	expected := "# Ballista\n\n*Large object (siege weapon)*\n\n**Armor Class** 15\n\n**Hit Points** 50\n\n" +
+		"**Damage Immunities** poison, psychic\n\n" +
+		"A ballista is a massive crossbow that fires heavy bolts.\n\n" +
+		"## Actions\n\n### Bolt\n\n(ranged weapon) +6 to hit, range 120/480 ft.\n\n" +
+		"**Source:** DMG, page 255\n"
+	if md != expected {
+		t.Errorf("objectToMarkdown() = %q, want %q", md, expected)
+	}
+}
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		value    float64
+		expected string
+	}{
+		{0, "0"},
+		{150, "150"},
+		{1000, "1,000"},
+		{1234567, "1,234,567"},
+		{0.5, "0.5"},
+		{-2500, "-2,500"},
+	}
+
+	for _, test := range tests {
+		result := formatNumber(test.value)
+		if result != test.expected {
+			t.Errorf("formatNumber(%v) = %s; want %s", test.value, result, test.expected)
+		}
+	}
+}
+
+func TestParseVehiclesAndObjects_WithMockData(t *testing.T) {
+	tempDir, err := os.MkdirTemp("", "vehicles-test")
+	if err != nil {
+		t.Fatalf("Failed to create temp dir: %v", err)
+	}
+	defer os.RemoveAll(tempDir)
+
+	dataDir := filepath.Join(tempDir, "data")
+	outDir := filepath.Join(tempDir, "out")
+	if err := os.MkdirAll(dataDir, 0755); err != nil {
+		t.Fatalf("Failed to create data dir: %v", err)
+	}
+
+	files := map[string]interface{}{
+		"vehicles.json": VehicleFile{Vehicle: []Vehicle{{Name: "Galley", Source: "GoS", VehicleType: "SHIP", Size: "G"}}},
+		"objects.json":  ObjectFile{Object: []Object{{Name: "Ballista", Source: "DMG", Size: "L", ObjectType: "SW"}}},
+	}
+	for filename, content := range files {
+		data, err := json.Marshal(content)
+		if err != nil {
+			t.Fatalf("Failed to marshal %s: %v", filename, err)
+		}
+		if err := os.WriteFile(filepath.Join(dataDir, filename), data, 0644); err != nil {
+			t.Fatalf("Failed to write %s: %v", filename, err)
+		}
+	}
+
+	ctx := context.Background()
+	if err := parseVehicles(ctx, dataDir, outDir); err != nil {
+		t.Fatalf("parseVehicles() error = %v", err)
+	}
+	if err := parseObjects(ctx, dataDir, outDir); err != nil {
+		t.Fatalf("parseObjects() error = %v", err)
+	}
+
+	expectedFiles := []string{
+		filepath.Join("vehicles", "Galley.md"),
+		filepath.Join("objects", "Ballista.md"),
+	}
+	for _, expectedFile := range expectedFiles {
+		if _, err := os.Stat(filepath.Join(outDir, expectedFile)); os.IsNotExist(err) {
+			t.Errorf("Expected file %s does not exist", expectedFile)
+		}
+	}
+}